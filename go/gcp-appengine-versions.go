@@ -0,0 +1,88 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/appengine/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// GaeVersion is the version returned by the App Engine Admin API
+type GaeVersion appengine.Version
+
+// AppEngineVersion stores App Engine versions.
+// Ingress is configured per service and copied from the service network settings into
+// IngressTrafficAllowed. App Engine has no invoker role, access is controlled by Identity-Aware Proxy.
+type AppEngineVersion struct {
+	*GaeVersion
+	SelfLink              string `json:"selfLink"`
+	IngressTrafficAllowed string `json:"ingressTrafficAllowed"`
+}
+
+func init() {
+	RegisterAssetHandler(&AppEngineVersion{})
+}
+
+func (z AppEngineVersion) AssetType() string {
+	return "appengine.googleapis.com/Version"
+}
+func (z AppEngineVersion) AssetTableID() string {
+	return "appengine_googleapis_com_Version"
+}
+
+// https://cloud.google.com/appengine/docs/admin-api/reference/rest/v1/apps.services.versions/get
+// https://cloud.google.com/appengine/docs/admin-api/reference/rest/v1/apps.services/get
+func (z *AppEngineVersion) GetAsset(assetName string) (AssetHandler, error) {
+	appEngineService, err := sharedAppEngineService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //appengine.googleapis.com/apps/APP/services/SERVICE/versions/VERSION
+	nameSplit := strings.Split(AssetRelativeName(assetName), "/")
+	if len(nameSplit) != 6 {
+		return nil, fmt.Errorf("AppEngineVersion:GetAsset %s is not a supported asset name", assetName)
+	}
+
+	asset, err := appEngineService.Apps.Services.Versions.Get(nameSplit[1], nameSplit[3], nameSplit[5]).View("FULL").Do()
+	if err != nil {
+		return nil, err
+	}
+	version := &AppEngineVersion{GaeVersion: (*GaeVersion)(asset), SelfLink: assetName}
+
+	service, err := appEngineService.Apps.Services.Get(nameSplit[1], nameSplit[3]).Do()
+	if err != nil {
+		// The version is still recorded when the service can not be read
+		if ServerlessDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: AppEngineVersion:GetAsset:Services.Get %s: %v \n", assetName, err)
+		}
+	} else if service.NetworkSettings != nil {
+		version.IngressTrafficAllowed = service.NetworkSettings.IngressTrafficAllowed
+	}
+
+	return version, nil
+}
+
+func (z AppEngineVersion) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(GaeVersion{})
+	if err != nil {
+		return nil, err
+	}
+
+	ingressSchema, err := InferSchema(struct {
+		IngressTrafficAllowed string
+	}{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, ingressSchema...), nil
+}
+
+func (z *AppEngineVersion) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.GaeVersion == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
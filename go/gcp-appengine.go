@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/appengine/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpAppEngineService() (*appengine.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, appengine.CloudPlatformReadOnlyScope)
+	if err != nil {
+		return nil, err
+	}
+	return appengine.New(client)
+}
+
+// sharedAppEngineService returns an appengine service that is created once and reused by every handler
+var sharedAppEngineService = cachedService(gcpAppEngineService)
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	bigquery "cloud.google.com/go/bigquery"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// AssetContent is a ListAssets content type other than RESOURCE. Every content type is written to its own
+// table, Rows flattens the content of a single asset into rows of the same type as Row.
+// Only the asset types of env.GOOGLE_CLOUD_ASSET_TYPES are listed, asset types that do not carry the content
+// type (e.g. ORG_POLICY is only set on projects, folders and organizations) return no rows.
+type AssetContent struct {
+	ContentType assetpb.ContentType
+	TableID     string
+	Row         interface{} // Row type used to infer the table schema
+	Rows        func(asset *assetpb.Asset) ([]interface{}, error)
+}
+
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/feeds#contenttype
+var assetContents = map[string]AssetContent{
+	"IAM_POLICY": {
+		ContentType: assetpb.ContentType_IAM_POLICY,
+		TableID:     "cloudasset_googleapis_com_IamPolicy",
+		Row:         AssetIamPolicyMember{},
+		Rows:        assetIamPolicyMembers,
+	},
+	"ORG_POLICY": {
+		ContentType: assetpb.ContentType_ORG_POLICY,
+		TableID:     "cloudasset_googleapis_com_OrgPolicy",
+		Row:         AssetOrgPolicy{},
+		Rows:        assetOrgPolicies,
+	},
+	"ACCESS_POLICY": {
+		ContentType: assetpb.ContentType_ACCESS_POLICY,
+		TableID:     "cloudasset_googleapis_com_AccessPolicy",
+		Row:         AssetAccessPolicy{},
+		Rows:        assetAccessPolicies,
+	},
+	"RELATIONSHIP": {
+		ContentType: assetpb.ContentType_RELATIONSHIP,
+		TableID:     "cloudasset_googleapis_com_Relationship",
+		Row:         AssetRelationship{},
+		Rows:        assetRelationships,
+	},
+}
+
+// LookupAssetContent returns the content type registered for name (e.g. IAM_POLICY)
+func LookupAssetContent(name string) (AssetContent, bool) {
+	content, exist := assetContents[strings.ToUpper(name)]
+	return content, exist
+}
+
+// AssetContentNames returns the names of the supported content types
+func AssetContentNames() []string {
+	var names []string
+	for name := range assetContents {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func (c AssetContent) GetSchema() (bigquery.Schema, error) {
+	schema, err := bigquery.InferSchema(c.Row)
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.Relax(), nil
+}
+
+// Refresh lists the content of every asset under parent as of readTime and replaces the content table,
+// point in time content is written to a dated table like the inventory
+func (c AssetContent) Refresh(projectID string, datasetID string, parent string, assetTypes []string, readTime time.Time) error {
+	assetList, err := listAssets(parent, assetTypes, c.ContentType, readTime)
+	if err != nil {
+		return fmt.Errorf("AssetContent:%s: %v", c.ContentType, err)
+	}
+
+	var rows []interface{}
+	for _, asset := range assetList {
+		assetRows, err := c.Rows(asset)
+		if err != nil {
+			return fmt.Errorf("AssetContent:%s: %s %v", c.ContentType, asset.Name, err)
+		}
+		rows = append(rows, assetRows...)
+	}
+
+	schema, err := c.GetSchema()
+	if err != nil {
+		return err
+	}
+	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: AssetContent:Refresh:%s Assets: %d Rows: %d\n", c.ContentType, len(assetList), len(rows))
+	}
+	return bqTableReplaceRows(projectID, datasetID, AssetInventoryTableID(c.TableID, readTime), schema, rows)
+}
+
+// AssetContentKey identifies the asset a content row belongs to, the columns match the inventory table
+type AssetContentKey struct {
+	Name        string
+	Asset_type  string
+	Ancestors   []string
+	Update_Time time.Time
+}
+
+func assetContentKey(asset *assetpb.Asset) AssetContentKey {
+	return AssetContentKey{
+		Name:        asset.GetName(),
+		Asset_type:  asset.GetAssetType(),
+		Ancestors:   asset.GetAncestors(),
+		Update_Time: time.Unix(asset.GetUpdateTime().GetSeconds(), int64(asset.GetUpdateTime().GetNanos())).UTC(),
+	}
+}
+
+// AssetIamPolicyMember has one row per member of an IAM binding set directly on the asset
+type AssetIamPolicyMember struct {
+	AssetContentKey
+	Role       string
+	Member     string
+	MemberType string // user, group, serviceAccount, domain, allUsers, ...
+	Condition  AssetIamCondition
+}
+
+type AssetIamCondition struct {
+	Title       string
+	Description string
+	Expression  string
+}
+
+func assetIamPolicyMembers(asset *assetpb.Asset) ([]interface{}, error) {
+	var rows []interface{}
+	for _, binding := range asset.GetIamPolicy().GetBindings() {
+		for _, member := range binding.GetMembers() {
+			rows = append(rows, AssetIamPolicyMember{
+				AssetContentKey: assetContentKey(asset),
+				Role:            binding.GetRole(),
+				Member:          member,
+				MemberType:      strings.SplitN(member, ":", 2)[0],
+				Condition: AssetIamCondition{
+					Title:       binding.GetCondition().GetTitle(),
+					Description: binding.GetCondition().GetDescription(),
+					Expression:  binding.GetCondition().GetExpression(),
+				},
+			})
+		}
+	}
+	return rows, nil
+}
+
+// AssetOrgPolicy has one row per organization policy set on the asset, PolicyType is BOOLEAN, LIST or
+// RESTORE_DEFAULT
+type AssetOrgPolicy struct {
+	AssetContentKey
+	Constraint        string
+	PolicyType        string
+	Enforced          bool
+	AllValues         string
+	AllowedValues     []string
+	DeniedValues      []string
+	InheritFromParent bool
+	SuggestedValue    string
+}
+
+func assetOrgPolicies(asset *assetpb.Asset) ([]interface{}, error) {
+	var rows []interface{}
+	for _, policy := range asset.GetOrgPolicy() {
+		row := AssetOrgPolicy{
+			AssetContentKey: assetContentKey(asset),
+			Constraint:      policy.GetConstraint(),
+		}
+		switch {
+		case policy.GetBooleanPolicy() != nil:
+			row.PolicyType = "BOOLEAN"
+			row.Enforced = policy.GetBooleanPolicy().GetEnforced()
+		case policy.GetListPolicy() != nil:
+			row.PolicyType = "LIST"
+			row.AllValues = policy.GetListPolicy().GetAllValues().String()
+			row.AllowedValues = policy.GetListPolicy().GetAllowedValues()
+			row.DeniedValues = policy.GetListPolicy().GetDeniedValues()
+			row.InheritFromParent = policy.GetListPolicy().GetInheritFromParent()
+			row.SuggestedValue = policy.GetListPolicy().GetSuggestedValue()
+		case policy.GetRestoreDefault() != nil:
+			row.PolicyType = "RESTORE_DEFAULT"
+		}
+		rows = append(rows, row)
+	}
+	return rows, nil
+}
+
+// AssetAccessPolicy has one row per access policy, access level or service perimeter, PolicyType is
+// ACCESS_POLICY, ACCESS_LEVEL or SERVICE_PERIMETER. Data is the JSON of the Access Context Manager resource.
+// Resources and RestrictedServices are read from the enforced configuration of service perimeters.
+type AssetAccessPolicy struct {
+	AssetContentKey
+	PolicyType         string
+	PolicyName         string
+	Title              string
+	PerimeterType      string
+	Resources          []string
+	RestrictedServices []string
+	Data               string
+}
+
+func assetAccessPolicies(asset *assetpb.Asset) ([]interface{}, error) {
+	row := AssetAccessPolicy{AssetContentKey: assetContentKey(asset)}
+
+	var data []byte
+	var err error
+	switch {
+	case asset.GetAccessPolicy() != nil:
+		row.PolicyType = "ACCESS_POLICY"
+		row.PolicyName = asset.GetAccessPolicy().GetName()
+		row.Title = asset.GetAccessPolicy().GetTitle()
+		data, err = protojson.Marshal(asset.GetAccessPolicy())
+	case asset.GetAccessLevel() != nil:
+		row.PolicyType = "ACCESS_LEVEL"
+		row.PolicyName = asset.GetAccessLevel().GetName()
+		row.Title = asset.GetAccessLevel().GetTitle()
+		data, err = protojson.Marshal(asset.GetAccessLevel())
+	case asset.GetServicePerimeter() != nil:
+		row.PolicyType = "SERVICE_PERIMETER"
+		row.PolicyName = asset.GetServicePerimeter().GetName()
+		row.Title = asset.GetServicePerimeter().GetTitle()
+		row.PerimeterType = asset.GetServicePerimeter().GetPerimeterType().String()
+		row.Resources = asset.GetServicePerimeter().GetStatus().GetResources()
+		row.RestrictedServices = asset.GetServicePerimeter().GetStatus().GetRestrictedServices()
+		data, err = protojson.Marshal(asset.GetServicePerimeter())
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("protojson.Marshal: %v", err)
+	}
+	row.Data = string(data)
+	return []interface{}{row}, nil
+}
+
+// AssetRelationship has one row per asset related to the asset, e.g. the disks attached to an instance
+type AssetRelationship struct {
+	AssetContentKey
+	RelationshipType string
+	RelatedAsset     string
+	RelatedAssetType string
+	RelatedAncestors []string
+}
+
+func assetRelationships(asset *assetpb.Asset) ([]interface{}, error) {
+	var rows []interface{}
+	relatedAssets := asset.GetRelatedAssets()
+	for _, relatedAsset := range relatedAssets.GetAssets() {
+		rows = append(rows, AssetRelationship{
+			AssetContentKey:  assetContentKey(asset),
+			RelationshipType: relatedAssets.GetRelationshipAttributes().GetType(),
+			RelatedAsset:     relatedAsset.GetAsset(),
+			RelatedAssetType: relatedAsset.GetAssetType(),
+			RelatedAncestors: relatedAsset.GetAncestors(),
+		})
+	}
+	return rows, nil
+}
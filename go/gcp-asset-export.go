@@ -0,0 +1,136 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	bigquery "cloud.google.com/go/bigquery"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// AssetExportTableID returns the table written by ExportAssets (e.g. cloudasset_googleapis_com_AssetExport),
+// every export is a partition of the same table so point in time exports use it as well
+func AssetExportTableID(assetInventoryTableID string) string {
+	return assetInventoryTableID + "Export"
+}
+
+// ExportInventory is the server side alternative to CollectAssets and RefreshInventory, ExportAssets writes
+// the assets to a table partitioned by readTime and the inventory table is rebuilt from the partition of
+// this export with a single INSERT, so no asset is held in memory. The export keeps resource.data as the
+// JSON string ListAssets returns so DiscoveryAsset and the views read the same Data in both collection modes,
+// per asset type output is not requested as it writes one table per type with resource.data split into columns.
+func (a *Asset) ExportInventory(projectID string, datasetID string, datasetRegion string, assetInventoryTableID string, assetExportTableID string, parent string, assetTypes []string) error {
+	datasetExist, err := bqDatasetExist(projectID, datasetID)
+	if err != nil {
+		return err
+	}
+	if !(datasetExist) {
+		if err := bqDatasetCreate(projectID, datasetID, datasetRegion); err != nil {
+			return err
+		}
+	}
+
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/exportAssets
+	request := &assetpb.ExportAssetsRequest{
+		Parent:      parent,
+		AssetTypes:  assetTypes[:],
+		ContentType: assetpb.ContentType_RESOURCE,
+		OutputConfig: &assetpb.OutputConfig{
+			Destination: &assetpb.OutputConfig_BigqueryDestination{
+				BigqueryDestination: &assetpb.BigQueryDestination{
+					Dataset: fmt.Sprintf("projects/%s/datasets/%s", projectID, datasetID),
+					Table:   assetExportTableID,
+					PartitionSpec: &assetpb.PartitionSpec{
+						PartitionKey: assetpb.PartitionSpec_READ_TIME,
+					},
+				},
+			},
+		},
+	}
+	// The current state is exported at the read time chosen by the API, a client clock ahead of the API
+	// would be rejected as a read time in the future
+	if !(a.ReadTime.IsZero()) {
+		request.ReadTime = timestamppb.New(a.ReadTime)
+	}
+	operation, err := client.ExportAssets(ctx, request)
+	if err != nil {
+		return fmt.Errorf("Asset:ExportInventory:ExportAssets: %v", err)
+	}
+	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: Asset:ExportInventory Operation: %s ReadTime: %s \n", operation.Name(), a.ReadTime.Format(time.RFC3339))
+	}
+	if _, err := operation.Wait(ctx); err != nil {
+		return fmt.Errorf("Asset:ExportInventory:Wait: %v", err)
+	}
+
+	// The partition of this export is the latest read time, or the read time that was requested
+	readTime := a.ReadTime
+	if readTime.IsZero() {
+		rows, err := bqExecutQuery(projectID, fmt.Sprintf(`SELECT MAX(readTime) FROM %s.%s.%s`, projectID, datasetID, assetExportTableID))
+		if err != nil {
+			return fmt.Errorf("Asset:ExportInventory: %v", err)
+		}
+		if len(rows) > 0 {
+			readTime, _ = rows[0].([]bigquery.Value)[0].(time.Time)
+		}
+		if readTime.IsZero() {
+			return fmt.Errorf("Asset:ExportInventory: %s.%s.%s has no exported partition", projectID, datasetID, assetExportTableID)
+		}
+	}
+
+	// The inventory table is re-created with the schema of Asset like RefreshInventory
+	tableExist, err := bqTableExist(projectID, datasetID, assetInventoryTableID)
+	if err != nil {
+		return err
+	}
+	if tableExist {
+		if err := bqTableDelete(projectID, datasetID, assetInventoryTableID); err != nil {
+			return err
+		}
+	}
+	schema, err := a.GetSchema()
+	if err != nil {
+		return err
+	}
+	if err := bqTableCreate(projectID, datasetID, assetInventoryTableID, schema); err != nil {
+		return err
+	}
+
+	var queryString = fmt.Sprintf(`
+		INSERT INTO %[1]s.%[2]s.%[3]s (Name, Asset_type, Ancestors, Update_Time, Resource)
+		SELECT
+			name,
+			asset_type,
+			ancestors,
+			update_time,
+			STRUCT(
+				resource.version AS Version,
+				resource.discovery_document_uri AS Discovery_document_url,
+				resource.discovery_name AS Discovery_name,
+				resource.resource_url AS Resource_url,
+				resource.parent AS Parent,
+				resource.data AS Data,
+				resource.location AS Location
+			)
+		FROM %[1]s.%[2]s.%[4]s
+		WHERE readTime = TIMESTAMP('%[5]s')`,
+		projectID, datasetID, assetInventoryTableID, assetExportTableID, readTime.UTC().Format(time.RFC3339Nano))
+	if _, err := bqExecutQuery(projectID, queryString); err != nil {
+		return fmt.Errorf("Asset:ExportInventory: %v", err)
+	}
+	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: Asset:ExportInventory DatasetID: %s TableID: %s ReadTime: %s \n", datasetID, assetInventoryTableID, readTime.Format(time.RFC3339Nano))
+	}
+	return nil
+}
@@ -0,0 +1,352 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	"cloud.google.com/go/pubsub"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+)
+
+var FeedDebugLevel = DebugLevel(ERROR)
+
+// Messages that fail are redelivered with a backoff and moved to the dead letter topic after
+// feedMaxDeliveryAttempts, so a failing message does not block the subscription
+const (
+	feedMinimumBackoff      = 10 * time.Second
+	feedMaximumBackoff      = 10 * time.Minute
+	feedMaxDeliveryAttempts = 10
+)
+
+// FeedCommand runs `feed [flags]`, it keeps the detailed tables up to date from the TemporalAsset messages a
+// Cloud Asset Feed publishes to Pub/Sub. The feed, topic and subscription are created or updated unless
+// -manage-feed=false. With PUBSUB_EMULATOR_HOST set the Pub/Sub client talks to the emulator, the feed can not
+// be emulated so -manage-feed=false is implied and TemporalAsset JSON messages are published by hand.
+// The inventory table is not updated, the next full run reconciles it.
+func FeedCommand(args []string) error {
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		return fmt.Errorf("env.GOOGLE_CLOUD_PROJECT environment variable must be set.")
+	}
+
+	flags := flag.NewFlagSet("feed", flag.ContinueOnError)
+	feedID := flags.String("feed-id", "gcp-asset-inventory", "Cloud Asset Feed created in the asset scope")
+	topicName := flags.String("topic", fmt.Sprintf("projects/%s/topics/gcp-asset-inventory-feed", projectID), "Pub/Sub topic the feed publishes to")
+	subscriptionID := flags.String("subscription", "gcp-asset-inventory-feed", "Pub/Sub subscription of the topic consumed by this command")
+	manageFeed := flags.Bool("manage-feed", os.Getenv("PUBSUB_EMULATOR_HOST") == "", "create or update the feed, topic and subscription")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	assetScope, _assetScope, err := assetScopeFromEnv()
+	if err != nil {
+		return err
+	}
+	datasetID := datasetIDFromEnv(_assetScope)
+	datasetExist, err := bqDatasetExist(projectID, datasetID)
+	if err != nil {
+		return err
+	}
+	if !(datasetExist) {
+		return fmt.Errorf("feed: dataset %s.%s does not exist, run a full inventory first", projectID, datasetID)
+	}
+	assetTypes := splitList(os.Getenv("GOOGLE_CLOUD_ASSET_TYPES"))
+	if len(assetTypes) == 0 {
+		return fmt.Errorf("env.GOOGLE_CLOUD_ASSET_TYPES environment variable must be set and contain atleast one item")
+	}
+
+	topicSplit := strings.Split(*topicName, "/")
+	if len(topicSplit) != 4 || topicSplit[0] != "projects" || topicSplit[2] != "topics" {
+		return fmt.Errorf("feed: -topic `%s` must be formatted as projects/PROJECT/topics/TOPIC", *topicName)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	pubsubClient, err := pubsub.NewClient(ctx, topicSplit[1])
+	if err != nil {
+		return fmt.Errorf("pubsub.NewClient: %v", err)
+	}
+	defer pubsubClient.Close()
+
+	subscription := pubsubClient.Subscription(*subscriptionID)
+	if *manageFeed {
+		topic, err := feedTopic(ctx, pubsubClient, topicSplit[3])
+		if err != nil {
+			return err
+		}
+		deadLetterTopic, err := feedTopic(ctx, pubsubClient, *subscriptionID+"-dead-letter")
+		if err != nil {
+			return err
+		}
+		if subscription, err = feedSubscription(ctx, pubsubClient, *subscriptionID, topic, deadLetterTopic); err != nil {
+			return err
+		}
+		if err := feedCreateOrUpdate(ctx, assetScope, *feedID, *topicName, assetTypes); err != nil {
+			return err
+		}
+	}
+
+	feed := assetFeed{writer: &bqAssetTableWriter{projectID: projectID, datasetID: datasetID, tables: map[string]bool{}}}
+	fmt.Printf("Feed listening on:> %s\n", subscription.String())
+	return feed.Receive(ctx, subscription)
+}
+
+// feedTopic returns the topic the feed publishes to, the topic is created when it does not exist
+func feedTopic(ctx context.Context, client *pubsub.Client, topicID string) (*pubsub.Topic, error) {
+	topic := client.Topic(topicID)
+	exist, err := topic.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub.Topic.Exists: %v", err)
+	}
+	if exist {
+		return topic, nil
+	}
+	if topic, err = client.CreateTopic(ctx, topicID); err != nil {
+		return nil, fmt.Errorf("pubsub.CreateTopic: %v", err)
+	}
+	return topic, nil
+}
+
+// feedSubscription returns the subscription consumed by FeedCommand, it is created when it does not exist and
+// the retry and dead letter policies of an existing subscription are updated. The Pub/Sub service account
+// needs publisher on deadLetterTopic and subscriber on the subscription to forward messages.
+func feedSubscription(ctx context.Context, client *pubsub.Client, subscriptionID string, topic *pubsub.Topic, deadLetterTopic *pubsub.Topic) (*pubsub.Subscription, error) {
+	retryPolicy := &pubsub.RetryPolicy{
+		MinimumBackoff: feedMinimumBackoff,
+		MaximumBackoff: feedMaximumBackoff,
+	}
+	deadLetterPolicy := &pubsub.DeadLetterPolicy{
+		DeadLetterTopic:     deadLetterTopic.String(),
+		MaxDeliveryAttempts: feedMaxDeliveryAttempts,
+	}
+
+	subscription := client.Subscription(subscriptionID)
+	exist, err := subscription.Exists(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("pubsub.Subscription.Exists: %v", err)
+	}
+	if exist {
+		update := pubsub.SubscriptionConfigToUpdate{RetryPolicy: retryPolicy, DeadLetterPolicy: deadLetterPolicy}
+		if _, err := subscription.Update(ctx, update); err != nil {
+			return nil, fmt.Errorf("pubsub.Subscription.Update: %v", err)
+		}
+		return subscription, nil
+	}
+	config := pubsub.SubscriptionConfig{Topic: topic, RetryPolicy: retryPolicy, DeadLetterPolicy: deadLetterPolicy}
+	if subscription, err = client.CreateSubscription(ctx, subscriptionID, config); err != nil {
+		return nil, fmt.Errorf("pubsub.CreateSubscription: %v", err)
+	}
+	return subscription, nil
+}
+
+// feedCreateOrUpdate creates the Cloud Asset Feed, an existing feed is updated with the asset types and topic
+//
+// https://cloud.google.com/asset-inventory/docs/monitoring-asset-changes
+func feedCreateOrUpdate(ctx context.Context, parent string, feedID string, topicName string, assetTypes []string) error {
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	feed := &assetpb.Feed{
+		Name:        fmt.Sprintf("%s/feeds/%s", parent, feedID),
+		AssetTypes:  assetTypes,
+		ContentType: assetpb.ContentType_RESOURCE,
+		FeedOutputConfig: &assetpb.FeedOutputConfig{
+			Destination: &assetpb.FeedOutputConfig_PubsubDestination{
+				PubsubDestination: &assetpb.PubsubDestination{Topic: topicName},
+			},
+		},
+	}
+
+	_, err = client.GetFeed(ctx, &assetpb.GetFeedRequest{Name: feed.Name})
+	if status.Code(err) == codes.NotFound {
+		if _, err := client.CreateFeed(ctx, &assetpb.CreateFeedRequest{Parent: parent, FeedId: feedID, Feed: feed}); err != nil {
+			return fmt.Errorf("CreateFeed: %v", err)
+		}
+		if FeedDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+			fmt.Printf("DEBUG: feedCreateOrUpdate:CREATE %s \n", feed.Name)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("GetFeed: %v", err)
+	}
+
+	request := &assetpb.UpdateFeedRequest{
+		Feed:       feed,
+		UpdateMask: &fieldmaskpb.FieldMask{Paths: []string{"asset_types", "content_type", "feed_output_config"}},
+	}
+	if _, err := client.UpdateFeed(ctx, request); err != nil {
+		return fmt.Errorf("UpdateFeed: %v", err)
+	}
+	if FeedDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: feedCreateOrUpdate:UPDATE %s \n", feed.Name)
+	}
+	return nil
+}
+
+// feedChange is the change of a single asset carried by a TemporalAsset message
+type feedChange struct {
+	AssetName    string
+	AssetTableID string
+	Action       AssetAction
+}
+
+// parseFeedChange decodes a TemporalAsset JSON message, a deleted asset is a DELETE and an asset whose prior
+// state did not exist is a CREATE, every other message is an UPDATE
+func parseFeedChange(data []byte) (feedChange, error) {
+	temporalAsset := &assetpb.TemporalAsset{}
+	if err := (protojson.UnmarshalOptions{DiscardUnknown: true}).Unmarshal(data, temporalAsset); err != nil {
+		return feedChange{}, fmt.Errorf("protojson.Unmarshal: %v", err)
+	}
+	if temporalAsset.GetAsset().GetName() == "" {
+		return feedChange{}, fmt.Errorf("TemporalAsset has no asset name")
+	}
+
+	change := feedChange{
+		AssetName:    temporalAsset.GetAsset().GetName(),
+		AssetTableID: AssetTableIDFromType(temporalAsset.GetAsset().GetAssetType()),
+		Action:       UPDATE,
+	}
+	if temporalAsset.GetDeleted() {
+		change.Action = DELETE
+	} else if temporalAsset.GetPriorAssetState() == assetpb.TemporalAsset_DOES_NOT_EXIST {
+		change.Action = CREATE
+	}
+	return change, nil
+}
+
+// assetTableWriter is the part of BigQuery the feed writes to, the detailed tables of the handlers
+type assetTableWriter interface {
+	CreateTable(handler AssetHandler) error
+	SelfLinks(assetTableID string, assetName string) ([]string, error)
+	Delete(assetTableID string, selfLink string) error
+	Insert(asset AssetHandler) error
+}
+
+// bqAssetTableWriter writes to the detailed tables of datasetID
+type bqAssetTableWriter struct {
+	projectID string
+	datasetID string
+	sync.Mutex
+	tables map[string]bool // Detailed tables already created by CreateAssetTable
+}
+
+func (w *bqAssetTableWriter) CreateTable(handler AssetHandler) error {
+	w.Lock()
+	defer w.Unlock()
+
+	if w.tables[handler.AssetTableID()] {
+		return nil
+	}
+	if err := CreateAssetTable(handler, w.projectID, w.datasetID); err != nil {
+		return err
+	}
+	w.tables[handler.AssetTableID()] = true
+	return nil
+}
+func (w *bqAssetTableWriter) SelfLinks(assetTableID string, assetName string) ([]string, error) {
+	return bqAssetSelfLinks(w.projectID, w.datasetID, assetTableID, assetName)
+}
+func (w *bqAssetTableWriter) Delete(assetTableID string, selfLink string) error {
+	return bqAssetDelete(w.projectID, w.datasetID, assetTableID, selfLink)
+}
+func (w *bqAssetTableWriter) Insert(asset AssetHandler) error {
+	return asset.InsertAssetBQ(w.projectID, w.datasetID)
+}
+
+// assetFeed applies TemporalAsset messages to the detailed tables of the registered handlers
+type assetFeed struct {
+	writer assetTableWriter
+}
+
+// Receive applies the messages of subscription until ctx is done
+func (f *assetFeed) Receive(ctx context.Context, subscription *pubsub.Subscription) error {
+	// Messages are applied one at a time so the delete and insert of an UPDATE do not race with another
+	// message of the same asset
+	subscription.ReceiveSettings.MaxOutstandingMessages = 1
+	subscription.ReceiveSettings.NumGoroutines = 1
+
+	return subscription.Receive(ctx, func(ctx context.Context, message *pubsub.Message) {
+		if err := f.Apply(message.Data); err != nil {
+			// Errors are retried by redelivering the message with the backoff of the subscription
+			fmt.Println(err)
+			message.Nack()
+			return
+		}
+		message.Ack()
+	})
+}
+
+// Apply applies a TemporalAsset JSON message. Errors of the writer and assets that can not be read are
+// returned, messages that can not be parsed, asset types without a handler and assets that no longer exist
+// are logged and dropped.
+func (f *assetFeed) Apply(data []byte) error {
+	change, err := parseFeedChange(data)
+	if err != nil {
+		fmt.Printf("ERROR: Feed:Apply:%v\n", err)
+		return nil
+	}
+	assetName, assetTableID, action := change.AssetName, change.AssetTableID, change.Action
+
+	// Discovery based tables need the inventory table and are only refreshed by a full run
+	handler, exist := LookupAssetHandler(assetTableID)
+	if !(exist) {
+		if FeedDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+			fmt.Printf("DEBUG: Feed:Apply no handler for %s %s\n", assetTableID, assetName)
+		}
+		return nil
+	}
+	if FeedDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: Feed:Apply:%s Action: %s Name: %s\n", assetTableID, action, assetName)
+	}
+
+	// The asset is read before the existing row is deleted so a failed read keeps the row, a resource that
+	// no longer exists is dropped as its DELETE message follows, other errors redeliver the message
+	var assetDetail AssetHandler
+	if action != DELETE {
+		if assetDetail, err = handler.GetAsset(assetName); err != nil {
+			if AssetNotFound(err) {
+				fmt.Printf("ERROR: Feed:Apply:%s:GetAsset %s: %v\n", assetTableID, assetName, err)
+				return nil
+			}
+			return fmt.Errorf("Feed:Apply:%s:GetAsset %s: %v", assetTableID, assetName, err)
+		}
+	}
+
+	if err := f.writer.CreateTable(handler); err != nil {
+		return err
+	}
+	// A CREATE is applied as an upsert too, the message may be redelivered and a full run may already have
+	// inserted the resource
+	selfLinks, err := f.writer.SelfLinks(assetTableID, assetName)
+	if err != nil {
+		return err
+	}
+	for _, selfLink := range selfLinks {
+		if err := f.writer.Delete(assetTableID, selfLink); err != nil {
+			return err
+		}
+	}
+	if action != DELETE {
+		if err := f.writer.Insert(assetDetail); err != nil {
+			return err
+		}
+	}
+	return nil
+}
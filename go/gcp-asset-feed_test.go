@@ -0,0 +1,279 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"golang.org/x/net/context"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/pubsub"
+)
+
+// feedTestAsset is a handler that does not call any API, it is registered for feedtest.googleapis.com/Asset
+type feedTestAsset struct {
+	name string
+}
+
+func init() {
+	RegisterAssetHandler(&feedTestAsset{})
+}
+
+func (z feedTestAsset) AssetType() string {
+	return "feedtest.googleapis.com/Asset"
+}
+func (z feedTestAsset) AssetTableID() string {
+	return "feedtest_googleapis_com_Asset"
+}
+func (z *feedTestAsset) GetAsset(assetName string) (AssetHandler, error) {
+	return &feedTestAsset{name: assetName}, nil
+}
+func (z feedTestAsset) GetSchema() (bigquery.Schema, error) {
+	return nil, nil
+}
+func (z *feedTestAsset) InsertAssetBQ(projectID string, datasetID string) error {
+	return fmt.Errorf("feedTestAsset is written by feedTestWriter")
+}
+
+// feedTestWriter records the calls of assetFeed, rows holds the assets that have a row named after them
+type feedTestWriter struct {
+	sync.Mutex
+	calls map[string][]string
+	rows  map[string]bool
+}
+
+func newFeedTestWriter(rows ...string) *feedTestWriter {
+	w := &feedTestWriter{calls: map[string][]string{}, rows: map[string]bool{}}
+	for _, row := range rows {
+		w.rows[row] = true
+	}
+	return w
+}
+
+func (w *feedTestWriter) record(assetName string, call string, row bool) {
+	w.Lock()
+	defer w.Unlock()
+	w.calls[assetName] = append(w.calls[assetName], call)
+	w.rows[assetName] = row
+}
+func (w *feedTestWriter) CreateTable(handler AssetHandler) error {
+	return nil
+}
+func (w *feedTestWriter) SelfLinks(assetTableID string, assetName string) ([]string, error) {
+	w.Lock()
+	defer w.Unlock()
+	if w.rows[assetName] {
+		return []string{assetName}, nil
+	}
+	return nil, nil
+}
+func (w *feedTestWriter) Delete(assetTableID string, selfLink string) error {
+	w.record(selfLink, "DELETE", false)
+	return nil
+}
+func (w *feedTestWriter) Insert(asset AssetHandler) error {
+	w.record(asset.(*feedTestAsset).name, "INSERT", true)
+	return nil
+}
+func (w *feedTestWriter) Calls(assetName string) string {
+	w.Lock()
+	defer w.Unlock()
+	return fmt.Sprint(w.calls[assetName])
+}
+
+func feedTestMessage(assetName string, priorAssetState string, deleted bool) []byte {
+	return []byte(fmt.Sprintf(`{"asset":{"name":"%s","assetType":"feedtest.googleapis.com/Asset"},"priorAssetState":"%s","deleted":%t}`,
+		assetName, priorAssetState, deleted))
+}
+
+func TestParseFeedChange(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		want    feedChange
+		wantErr bool
+	}{
+		{
+			name: "create",
+			data: feedTestMessage("//feedtest.googleapis.com/assets/a", "DOES_NOT_EXIST", false),
+			want: feedChange{AssetName: "//feedtest.googleapis.com/assets/a", AssetTableID: "feedtest_googleapis_com_Asset", Action: CREATE},
+		},
+		{
+			name: "update",
+			data: feedTestMessage("//feedtest.googleapis.com/assets/a", "PRESENT", false),
+			want: feedChange{AssetName: "//feedtest.googleapis.com/assets/a", AssetTableID: "feedtest_googleapis_com_Asset", Action: UPDATE},
+		},
+		{
+			name: "update without prior state",
+			data: []byte(`{"asset":{"name":"//feedtest.googleapis.com/assets/a","assetType":"feedtest.googleapis.com/Asset"}}`),
+			want: feedChange{AssetName: "//feedtest.googleapis.com/assets/a", AssetTableID: "feedtest_googleapis_com_Asset", Action: UPDATE},
+		},
+		{
+			name: "delete",
+			data: feedTestMessage("//feedtest.googleapis.com/assets/a", "PRESENT", true),
+			want: feedChange{AssetName: "//feedtest.googleapis.com/assets/a", AssetTableID: "feedtest_googleapis_com_Asset", Action: DELETE},
+		},
+		{
+			name: "unknown fields",
+			data: []byte(`{"asset":{"name":"//feedtest.googleapis.com/assets/a","assetType":"feedtest.googleapis.com/Asset","newField":1},"window":{"startTime":"2021-01-01T00:00:00Z"}}`),
+			want: feedChange{AssetName: "//feedtest.googleapis.com/assets/a", AssetTableID: "feedtest_googleapis_com_Asset", Action: UPDATE},
+		},
+		{
+			name:    "invalid json",
+			data:    []byte(`{"asset":`),
+			wantErr: true,
+		},
+		{
+			name:    "no asset name",
+			data:    []byte(`{"deleted":true}`),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFeedChange(tt.data)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseFeedChange() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parseFeedChange() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFeedApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rows     []string
+		messages [][]byte
+		want     string
+	}{
+		{
+			name:     "create",
+			messages: [][]byte{feedTestMessage("//feedtest.googleapis.com/assets/a", "DOES_NOT_EXIST", false)},
+			want:     "[INSERT]",
+		},
+		{
+			name: "redelivered create",
+			messages: [][]byte{
+				feedTestMessage("//feedtest.googleapis.com/assets/a", "DOES_NOT_EXIST", false),
+				feedTestMessage("//feedtest.googleapis.com/assets/a", "DOES_NOT_EXIST", false),
+			},
+			want: "[INSERT DELETE INSERT]",
+		},
+		{
+			name:     "create after a full run",
+			rows:     []string{"//feedtest.googleapis.com/assets/a"},
+			messages: [][]byte{feedTestMessage("//feedtest.googleapis.com/assets/a", "DOES_NOT_EXIST", false)},
+			want:     "[DELETE INSERT]",
+		},
+		{
+			name:     "update",
+			rows:     []string{"//feedtest.googleapis.com/assets/a"},
+			messages: [][]byte{feedTestMessage("//feedtest.googleapis.com/assets/a", "PRESENT", false)},
+			want:     "[DELETE INSERT]",
+		},
+		{
+			name:     "delete",
+			rows:     []string{"//feedtest.googleapis.com/assets/a"},
+			messages: [][]byte{feedTestMessage("//feedtest.googleapis.com/assets/a", "PRESENT", true)},
+			want:     "[DELETE]",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			writer := newFeedTestWriter(tt.rows...)
+			feed := assetFeed{writer: writer}
+			for _, message := range tt.messages {
+				if err := feed.Apply(message); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := writer.Calls("//feedtest.googleapis.com/assets/a"); got != tt.want {
+				t.Errorf("Apply() calls = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+// TestFeedEmulator publishes TemporalAsset messages to the Pub/Sub emulator and checks the feed dispatches
+// them, run `gcloud beta emulators pubsub start` and export PUBSUB_EMULATOR_HOST first
+func TestFeedEmulator(t *testing.T) {
+	if os.Getenv("PUBSUB_EMULATOR_HOST") == "" {
+		t.Skip("PUBSUB_EMULATOR_HOST is not set")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	client, err := pubsub.NewClient(ctx, "feed-test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer client.Close()
+
+	id := fmt.Sprintf("feed-test-%d", time.Now().UnixNano())
+	topic, err := feedTopic(ctx, client, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer topic.Stop()
+	deadLetterTopic, err := feedTopic(ctx, client, id+"-dead-letter")
+	if err != nil {
+		t.Fatal(err)
+	}
+	subscription, err := feedSubscription(ctx, client, id, topic, deadLetterTopic)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]string{
+		"//feedtest.googleapis.com/assets/created": "[INSERT]",
+		"//feedtest.googleapis.com/assets/updated": "[DELETE INSERT]",
+		"//feedtest.googleapis.com/assets/deleted": "[DELETE]",
+	}
+	messages := [][]byte{
+		feedTestMessage("//feedtest.googleapis.com/assets/created", "DOES_NOT_EXIST", false),
+		feedTestMessage("//feedtest.googleapis.com/assets/updated", "PRESENT", false),
+		feedTestMessage("//feedtest.googleapis.com/assets/deleted", "PRESENT", true),
+	}
+	for _, message := range messages {
+		if _, err := topic.Publish(ctx, &pubsub.Message{Data: message}).Get(ctx); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	writer := newFeedTestWriter("//feedtest.googleapis.com/assets/updated", "//feedtest.googleapis.com/assets/deleted")
+	feed := assetFeed{writer: writer}
+	receiveCtx, stop := context.WithCancel(ctx)
+	go func() {
+		// Receive runs until every message has been applied
+		defer stop()
+		for {
+			done := true
+			for assetName, calls := range want {
+				done = done && writer.Calls(assetName) == calls
+			}
+			if done {
+				return
+			}
+			select {
+			case <-receiveCtx.Done():
+				return
+			case <-time.After(100 * time.Millisecond):
+			}
+		}
+	}()
+	if err := feed.Receive(receiveCtx, subscription); err != nil {
+		t.Fatal(err)
+	}
+
+	for assetName, calls := range want {
+		if got := writer.Calls(assetName); got != calls {
+			t.Errorf("%s: calls = %s, want %s", assetName, got, calls)
+		}
+	}
+}
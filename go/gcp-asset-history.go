@@ -0,0 +1,174 @@
+package main
+
+import (
+	"fmt"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	bigquery "cloud.google.com/go/bigquery"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// BatchGetAssetsHistory accepts at most 100 asset names per request
+const assetHistoryBatchSize = 100
+
+// BatchGetAssetsHistory rejects windows starting more than 35 days before the request, the margin covers
+// clock skew between the client and the API
+const (
+	assetHistoryMaxDays = 35
+	assetHistoryMargin  = 5 * time.Minute
+)
+
+// assetHistoryStartTime returns the start of the window of a request sent at now, the start is computed per
+// request because a large inventory takes long enough for a window computed once to fall out of 35 days
+func assetHistoryStartTime(now time.Time, days int) time.Time {
+	if days > assetHistoryMaxDays {
+		days = assetHistoryMaxDays
+	}
+	startTime := now.AddDate(0, 0, -days)
+	if days == assetHistoryMaxDays {
+		startTime = startTime.Add(assetHistoryMargin)
+	}
+	return startTime
+}
+
+// AssetHistory is one version of an asset, the version was current between WindowStartTime and
+// WindowEndTime, WindowEndTime is NULL for the current version. Deleted versions have no resource data. The columns of Asset are repeated instead of
+// embedding Asset so the fields Asset only keeps in memory are not written to the table.
+type AssetHistory struct {
+	Name            string
+	Asset_type      string
+	Ancestors       []string
+	Update_Time     time.Time
+	Resource        AssetResource
+	WindowStartTime time.Time
+	WindowEndTime   bigquery.NullTimestamp
+	Deleted         bool
+}
+
+func (h *AssetHistory) GetSchema() (bigquery.Schema, error) {
+	schema, err := bigquery.InferSchema(AssetHistory{})
+	if err != nil {
+		return nil, err
+	}
+
+	return schema.Relax(), nil
+}
+
+// assetHistoryEndTime returns NULL for a window without end time, the version is still current
+func assetHistoryEndTime(endTime *timestamppb.Timestamp) bigquery.NullTimestamp {
+	if endTime == nil {
+		return bigquery.NullTimestamp{}
+	}
+	return bigquery.NullTimestamp{Timestamp: endTime.AsTime(), Valid: true}
+}
+
+// assetHistoryRequest returns the request for the history of assetNames, the window starts days before now
+func assetHistoryRequest(parent string, assetNames []string, days int) *assetpb.BatchGetAssetsHistoryRequest {
+	return &assetpb.BatchGetAssetsHistoryRequest{
+		Parent:         parent,
+		AssetNames:     assetNames,
+		ContentType:    assetpb.ContentType_RESOURCE,
+		ReadTimeWindow: &assetpb.TimeWindow{StartTime: timestamppb.New(assetHistoryStartTime(time.Now(), days))},
+	}
+}
+
+// assetHistoryEach requests the history of one asset at a time, assets rejected by the API (e.g. deleted since
+// the inventory was loaded) are skipped
+func assetHistoryEach(ctx context.Context, client *asset.Client, parent string, assetNames []string, days int) ([]*assetpb.TemporalAsset, error) {
+	var temporalAssets []*assetpb.TemporalAsset
+	for _, assetName := range assetNames {
+		response, err := client.BatchGetAssetsHistory(ctx, assetHistoryRequest(parent, []string{assetName}, days))
+		if status.Code(err) == codes.InvalidArgument {
+			if AssetDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+				fmt.Printf("WARNING: CollectAssetHistory:BatchGetAssetsHistory %s: %v \n", assetName, err)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("CollectAssetHistory:BatchGetAssetsHistory %s: %v", assetName, err)
+		}
+		temporalAssets = append(temporalAssets, response.GetAssets()...)
+	}
+	return temporalAssets, nil
+}
+
+// AssetHistoryTableID returns the history table of an inventory table (e.g. cloudasset_googleapis_com_AssetHistory)
+func AssetHistoryTableID(assetInventoryTableID string) string {
+	return assetInventoryTableID + "History"
+}
+
+// CollectAssetHistory calls BatchGetAssetsHistory for every asset of the inventory table and replaces
+// assetHistoryTableID with every version of the last days (at most 35). parent must be an organization or project.
+func CollectAssetHistory(projectID string, datasetID string, assetInventoryTableID string, assetHistoryTableID string, parent string, days int) error {
+	rows, err := bqExecutQuery(projectID, fmt.Sprintf(`SELECT DISTINCT name FROM %s.%s.%s ORDER BY name`, projectID, datasetID, assetInventoryTableID))
+	if err != nil {
+		return fmt.Errorf("CollectAssetHistory: %v", err)
+	}
+	var assetNames []string
+	for _, row := range rows {
+		if name, ok := row.([]bigquery.Value)[0].(string); ok {
+			assetNames = append(assetNames, name)
+		}
+	}
+
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/batchGetAssetsHistory
+	var history []interface{}
+	for start := 0; start < len(assetNames); start += assetHistoryBatchSize {
+		end := start + assetHistoryBatchSize
+		if end > len(assetNames) {
+			end = len(assetNames)
+		}
+		var temporalAssets []*assetpb.TemporalAsset
+		response, err := client.BatchGetAssetsHistory(ctx, assetHistoryRequest(parent, assetNames[start:end], days))
+		if status.Code(err) == codes.InvalidArgument {
+			// The whole batch is rejected when one of its assets no longer exists
+			temporalAssets, err = assetHistoryEach(ctx, client, parent, assetNames[start:end], days)
+			if err != nil {
+				return err
+			}
+		} else if err != nil {
+			return fmt.Errorf("CollectAssetHistory:BatchGetAssetsHistory: %v", err)
+		} else {
+			temporalAssets = response.GetAssets()
+		}
+		for _, temporalAsset := range temporalAssets {
+			inventory, err := inventoryAsset(temporalAsset.GetAsset())
+			if err != nil {
+				return err
+			}
+			window := temporalAsset.GetWindow()
+			history = append(history, AssetHistory{
+				Name:            inventory.Name,
+				Asset_type:      inventory.Asset_type,
+				Ancestors:       inventory.Ancestors,
+				Update_Time:     inventory.Update_Time,
+				Resource:        inventory.Resource,
+				WindowStartTime: time.Unix(window.GetStartTime().GetSeconds(), int64(window.GetStartTime().GetNanos())).UTC(),
+				WindowEndTime:   assetHistoryEndTime(window.GetEndTime()),
+				Deleted:         temporalAsset.GetDeleted(),
+			})
+		}
+		if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+			fmt.Printf("DEBUG: CollectAssetHistory Assets: %d-%d of %d Versions: %d\n", start, end, len(assetNames), len(history))
+		}
+	}
+
+	schema, err := (&AssetHistory{}).GetSchema()
+	if err != nil {
+		return err
+	}
+	return bqTableReplaceRows(projectID, datasetID, assetHistoryTableID, schema, history)
+}
@@ -0,0 +1,211 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"golang.org/x/net/context"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	bigquery "cloud.google.com/go/bigquery"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+)
+
+// IamPolicyAnalysisRow is one identity with one access on one resource, expanded from an IAM binding.
+// Identities are the members of expanded groups when -expand-groups is set, Group is the group they were
+// expanded from. Resource is a full resource name (e.g. //compute.googleapis.com/projects/P/zones/Z/instances/I)
+// so "who can reach this VM" joins on the asset name of the inventory.
+type IamPolicyAnalysisRow struct {
+	AttachedResource    string // Resource the IAM policy is set on
+	Role                string
+	Condition           AssetIamCondition
+	ConditionEvaluation string
+	Resource            string
+	AccessType          string // ROLE or PERMISSION
+	Access              string
+	Identity            string
+	Group               string
+	FullyExplored       bool
+}
+
+// IamPolicyAnalysisTableID is the table written by AnalyzeIamPolicy, AnalyzeIamPolicyLongrunning uses it as the
+// prefix of its own _analysis and _analysis_result tables
+const IamPolicyAnalysisTableID = "cloudasset_googleapis_com_IamPolicyAnalysis"
+
+// AnalyzeIamCommand runs `analyze-iam [flags]` over env.GOOGLE_CLOUD_ASSET_SCOPE and writes the expanded access
+// into the inventory dataset. AnalyzeIamPolicy is limited in time and size, -longrunning lets the API write
+// the result to BigQuery itself.
+//
+// https://cloud.google.com/asset-inventory/docs/analyzing-iam-policy
+func AnalyzeIamCommand(args []string) error {
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		return fmt.Errorf("env.GOOGLE_CLOUD_PROJECT environment variable must be set.")
+	}
+
+	flags := flag.NewFlagSet("analyze-iam", flag.ContinueOnError)
+	identity := flags.String("identity", "", "identity to analyze, e.g. user:jane@example.com or group:team@example.com")
+	resource := flags.String("resource", "", "full resource name to analyze, e.g. //compute.googleapis.com/projects/P/zones/Z/instances/I")
+	roles := flags.String("roles", "", "comma separated roles to analyze")
+	permissions := flags.String("permissions", "", "comma separated permissions to analyze, e.g. compute.instances.setMetadata")
+	expandGroups := flags.Bool("expand-groups", true, "expand groups into their members")
+	expandRoles := flags.Bool("expand-roles", false, "expand roles into their permissions")
+	expandResources := flags.Bool("expand-resources", false, "expand resources into their descendants")
+	longrunning := flags.Bool("longrunning", false, "use AnalyzeIamPolicyLongrunning and let the API write the BigQuery tables")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *identity == "" && *resource == "" {
+		return fmt.Errorf("analyze-iam: -identity or -resource must be set")
+	}
+
+	assetScope, _assetScope, err := assetScopeFromEnv()
+	if err != nil {
+		return err
+	}
+	datasetID := datasetIDFromEnv(_assetScope)
+	datasetExist, err := bqDatasetExist(projectID, datasetID)
+	if err != nil {
+		return err
+	}
+	if !(datasetExist) {
+		return fmt.Errorf("analyze-iam: dataset %s.%s does not exist, run a full inventory first", projectID, datasetID)
+	}
+
+	query := &assetpb.IamPolicyAnalysisQuery{
+		Scope: assetScope,
+		Options: &assetpb.IamPolicyAnalysisQuery_Options{
+			ExpandGroups:     *expandGroups,
+			ExpandRoles:      *expandRoles,
+			ExpandResources:  *expandResources,
+			OutputGroupEdges: *expandGroups,
+		},
+	}
+	if *identity != "" {
+		query.IdentitySelector = &assetpb.IamPolicyAnalysisQuery_IdentitySelector{Identity: *identity}
+	}
+	if *resource != "" {
+		query.ResourceSelector = &assetpb.IamPolicyAnalysisQuery_ResourceSelector{FullResourceName: *resource}
+	}
+	if *roles != "" || *permissions != "" {
+		query.AccessSelector = &assetpb.IamPolicyAnalysisQuery_AccessSelector{
+			Roles:       splitList(*roles),
+			Permissions: splitList(*permissions),
+		}
+	}
+
+	if *longrunning {
+		return analyzeIamPolicyLongrunning(projectID, datasetID, query)
+	}
+	rows, err := analyzeIamPolicy(query)
+	if err != nil {
+		return err
+	}
+	schema, err := bigquery.InferSchema(IamPolicyAnalysisRow{})
+	if err != nil {
+		return err
+	}
+	if err := bqTableReplaceRows(projectID, datasetID, IamPolicyAnalysisTableID, schema.Relax(), rows); err != nil {
+		return err
+	}
+	fmt.Printf("%d access results written to %s.%s.%s\n", len(rows), projectID, datasetID, IamPolicyAnalysisTableID)
+	return nil
+}
+
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/analyzeIamPolicy
+func analyzeIamPolicy(query *assetpb.IamPolicyAnalysisQuery) ([]interface{}, error) {
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	response, err := client.AnalyzeIamPolicy(ctx, &assetpb.AnalyzeIamPolicyRequest{AnalysisQuery: query})
+	if err != nil {
+		return nil, fmt.Errorf("AnalyzeIamPolicy: %v", err)
+	}
+	if !(response.GetFullyExplored()) {
+		fmt.Println("WARNING: AnalyzeIamPolicy did not fully explore the scope, try -longrunning")
+	}
+
+	var rows []interface{}
+	for _, result := range response.GetMainAnalysis().GetAnalysisResults() {
+		binding := result.GetIamBinding()
+
+		// Group edges link an expanded identity to the group it is a member of
+		groups := map[string]string{}
+		for _, edge := range result.GetIdentityList().GetGroupEdges() {
+			groups[edge.GetTargetNode()] = edge.GetSourceNode()
+		}
+
+		for _, accessControlList := range result.GetAccessControlLists() {
+			for _, aclResource := range accessControlList.GetResources() {
+				for _, access := range accessControlList.GetAccesses() {
+					accessType, accessName := "ROLE", access.GetRole()
+					if access.GetPermission() != "" {
+						accessType, accessName = "PERMISSION", access.GetPermission()
+					}
+					for _, identity := range result.GetIdentityList().GetIdentities() {
+						rows = append(rows, IamPolicyAnalysisRow{
+							AttachedResource: result.GetAttachedResourceFullName(),
+							Role:             binding.GetRole(),
+							Condition: AssetIamCondition{
+								Title:       binding.GetCondition().GetTitle(),
+								Description: binding.GetCondition().GetDescription(),
+								Expression:  binding.GetCondition().GetExpression(),
+							},
+							ConditionEvaluation: accessControlList.GetConditionEvaluation().GetEvaluationValue().String(),
+							Resource:            aclResource.GetFullResourceName(),
+							AccessType:          accessType,
+							Access:              accessName,
+							Identity:            identity.GetName(),
+							Group:               groups[identity.GetName()],
+							FullyExplored:       result.GetFullyExplored(),
+						})
+					}
+				}
+			}
+		}
+	}
+	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: analyzeIamPolicy Results: %d Rows: %d\n", len(response.GetMainAnalysis().GetAnalysisResults()), len(rows))
+	}
+	return rows, nil
+}
+
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/analyzeIamPolicyLongrunning
+func analyzeIamPolicyLongrunning(projectID string, datasetID string, query *assetpb.IamPolicyAnalysisQuery) error {
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	request := &assetpb.AnalyzeIamPolicyLongrunningRequest{
+		AnalysisQuery: query,
+		OutputConfig: &assetpb.IamPolicyAnalysisOutputConfig{
+			Destination: &assetpb.IamPolicyAnalysisOutputConfig_BigqueryDestination{
+				BigqueryDestination: &assetpb.IamPolicyAnalysisOutputConfig_BigQueryDestination{
+					Dataset:          fmt.Sprintf("projects/%s/datasets/%s", projectID, datasetID),
+					TablePrefix:      IamPolicyAnalysisTableID,
+					WriteDisposition: "WRITE_TRUNCATE",
+				},
+			},
+		},
+	}
+	operation, err := client.AnalyzeIamPolicyLongrunning(ctx, request)
+	if err != nil {
+		return fmt.Errorf("AnalyzeIamPolicyLongrunning: %v", err)
+	}
+	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: analyzeIamPolicyLongrunning Operation: %s \n", operation.Name())
+	}
+	if _, err := operation.Wait(ctx); err != nil {
+		return fmt.Errorf("AnalyzeIamPolicyLongrunning:Wait: %v", err)
+	}
+	fmt.Printf("Analysis written to %s.%s.%s_analysis and %s_analysis_result\n", projectID, datasetID, IamPolicyAnalysisTableID, IamPolicyAnalysisTableID)
+	return nil
+}
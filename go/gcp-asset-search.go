@@ -0,0 +1,274 @@
+package main
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"golang.org/x/net/context"
+	"google.golang.org/api/iterator"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	asset "cloud.google.com/go/asset/apiv1"
+	bigquery "cloud.google.com/go/bigquery"
+	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+)
+
+// searchRow is a search result, Columns and Record are the fields written by the table and csv formats.
+// The json and bigquery formats keep every field of the row.
+type searchRow interface {
+	Columns() []string
+	Record() []string
+}
+
+// SearchResource is a result of SearchAllResources
+type SearchResource struct {
+	Name                   string
+	AssetType              string
+	Project                string
+	Folders                []string
+	Organization           string
+	DisplayName            string
+	Description            string
+	Location               string
+	State                  string
+	Labels                 []*ResourceLabel
+	NetworkTags            []string
+	ParentFullResourceName string
+	CreateTime             time.Time
+	UpdateTime             time.Time
+	AdditionalAttributes   string // JSON
+}
+
+func (r SearchResource) Columns() []string {
+	return []string{"NAME", "ASSET_TYPE", "PROJECT", "LOCATION", "STATE", "DISPLAY_NAME", "UPDATE_TIME"}
+}
+func (r SearchResource) Record() []string {
+	return []string{r.Name, r.AssetType, r.Project, r.Location, r.State, r.DisplayName, r.UpdateTime.Format(time.RFC3339)}
+}
+
+// SearchIamPolicyMember is one member of an IAM binding returned by SearchAllIamPolicies
+type SearchIamPolicyMember struct {
+	Resource     string
+	AssetType    string
+	Project      string
+	Folders      []string
+	Organization string
+	Role         string
+	Member       string
+	MemberType   string
+	Condition    AssetIamCondition
+}
+
+func (r SearchIamPolicyMember) Columns() []string {
+	return []string{"RESOURCE", "ASSET_TYPE", "ROLE", "MEMBER", "CONDITION"}
+}
+func (r SearchIamPolicyMember) Record() []string {
+	return []string{r.Resource, r.AssetType, r.Role, r.Member, r.Condition.Expression}
+}
+
+// SearchCommand runs `search [flags]`, the scope is read from env.GOOGLE_CLOUD_ASSET_SCOPE and the asset types
+// default to env.GOOGLE_CLOUD_ASSET_TYPES. The bigquery format replaces a table of the inventory dataset.
+//
+// https://cloud.google.com/asset-inventory/docs/query-syntax
+func SearchCommand(args []string) error {
+	kinds := []string{"resources", "iam-policies"}
+	formats := []string{"table", "json", "csv", "bigquery"}
+
+	flags := flag.NewFlagSet("search", flag.ContinueOnError)
+	query := flags.String("query", "", "search query, e.g. 'name:prod AND location:us-central1'")
+	kind := flags.String("kind", "resources", fmt.Sprintf("what to search, one of %v", kinds))
+	assetTypes := flags.String("asset-types", os.Getenv("GOOGLE_CLOUD_ASSET_TYPES"), "comma separated asset types, empty searches every searchable type")
+	format := flags.String("format", "table", fmt.Sprintf("output format, one of %v", formats))
+	tableID := flags.String("table", "", "BigQuery table of the bigquery format, defaults to cloudasset_googleapis_com_ResourceSearch or cloudasset_googleapis_com_IamPolicySearch")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if !(contains(kinds, *kind)) {
+		return fmt.Errorf("search: -kind `%s` is not one of %v", *kind, kinds)
+	}
+	if !(contains(formats, *format)) {
+		return fmt.Errorf("search: -format `%s` is not one of %v", *format, formats)
+	}
+
+	assetScope, _assetScope, err := assetScopeFromEnv()
+	if err != nil {
+		return err
+	}
+	searchAssetTypes := splitList(*assetTypes)
+
+	var rows []searchRow
+	var rowType interface{} // Row type used to infer the table schema
+	if *kind == "resources" {
+		rows, err = searchAllResources(assetScope, *query, searchAssetTypes)
+		rowType = SearchResource{}
+		if *tableID == "" {
+			*tableID = "cloudasset_googleapis_com_ResourceSearch"
+		}
+	} else {
+		rows, err = searchAllIamPolicies(assetScope, *query, searchAssetTypes)
+		rowType = SearchIamPolicyMember{}
+		if *tableID == "" {
+			*tableID = "cloudasset_googleapis_com_IamPolicySearch"
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	switch *format {
+	case "json":
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if rows == nil {
+			rows = []searchRow{}
+		}
+		return encoder.Encode(rows)
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		if len(rows) > 0 {
+			writer.Write(rows[0].Columns())
+		}
+		for _, row := range rows {
+			writer.Write(row.Record())
+		}
+		writer.Flush()
+		return writer.Error()
+	case "bigquery":
+		projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+		if projectID == "" {
+			return fmt.Errorf("env.GOOGLE_CLOUD_PROJECT environment variable must be set.")
+		}
+		datasetID := datasetIDFromEnv(_assetScope)
+		datasetExist, err := bqDatasetExist(projectID, datasetID)
+		if err != nil {
+			return err
+		}
+		if !(datasetExist) {
+			return fmt.Errorf("search: dataset %s.%s does not exist", projectID, datasetID)
+		}
+		schema, err := bigquery.InferSchema(rowType)
+		if err != nil {
+			return err
+		}
+		var tableRows []interface{}
+		for _, row := range rows {
+			tableRows = append(tableRows, row)
+		}
+		if err := bqTableReplaceRows(projectID, datasetID, *tableID, schema.Relax(), tableRows); err != nil {
+			return err
+		}
+		fmt.Printf("%d results written to %s.%s.%s\n", len(rows), projectID, datasetID, *tableID)
+		return nil
+	default:
+		writer := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		if len(rows) > 0 {
+			fmt.Fprintln(writer, strings.Join(rows[0].Columns(), "\t"))
+		}
+		for _, row := range rows {
+			fmt.Fprintln(writer, strings.Join(row.Record(), "\t"))
+		}
+		return writer.Flush()
+	}
+}
+
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/searchAllResources
+func searchAllResources(scope string, query string, assetTypes []string) ([]searchRow, error) {
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	request := &assetpb.SearchAllResourcesRequest{
+		Scope:      scope,
+		Query:      query,
+		AssetTypes: assetTypes,
+	}
+	response := client.SearchAllResources(ctx, request)
+	var rows []searchRow
+	for {
+		result, err := response.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("SearchAllResources: %v", err)
+		}
+		additionalAttributes, err := protojson.Marshal(result.GetAdditionalAttributes())
+		if err != nil {
+			return nil, fmt.Errorf("protojson.Marshal: %v", err)
+		}
+		rows = append(rows, SearchResource{
+			Name:                   result.GetName(),
+			AssetType:              result.GetAssetType(),
+			Project:                result.GetProject(),
+			Folders:                result.GetFolders(),
+			Organization:           result.GetOrganization(),
+			DisplayName:            result.GetDisplayName(),
+			Description:            result.GetDescription(),
+			Location:               result.GetLocation(),
+			State:                  result.GetState(),
+			Labels:                 resourceLabels(result.GetLabels()),
+			NetworkTags:            result.GetNetworkTags(),
+			ParentFullResourceName: result.GetParentFullResourceName(),
+			CreateTime:             time.Unix(result.GetCreateTime().GetSeconds(), int64(result.GetCreateTime().GetNanos())).UTC(),
+			UpdateTime:             time.Unix(result.GetUpdateTime().GetSeconds(), int64(result.GetUpdateTime().GetNanos())).UTC(),
+			AdditionalAttributes:   string(additionalAttributes),
+		})
+	}
+	return rows, nil
+}
+
+// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/TopLevel/searchAllIamPolicies
+func searchAllIamPolicies(scope string, query string, assetTypes []string) ([]searchRow, error) {
+	ctx := context.Background()
+	client, err := asset.NewClient(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	request := &assetpb.SearchAllIamPoliciesRequest{
+		Scope:      scope,
+		Query:      query,
+		AssetTypes: assetTypes,
+	}
+	response := client.SearchAllIamPolicies(ctx, request)
+	var rows []searchRow
+	for {
+		result, err := response.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("SearchAllIamPolicies: %v", err)
+		}
+		for _, binding := range result.GetPolicy().GetBindings() {
+			for _, member := range binding.GetMembers() {
+				rows = append(rows, SearchIamPolicyMember{
+					Resource:     result.GetResource(),
+					AssetType:    result.GetAssetType(),
+					Project:      result.GetProject(),
+					Folders:      result.GetFolders(),
+					Organization: result.GetOrganization(),
+					Role:         binding.GetRole(),
+					Member:       member,
+					MemberType:   strings.SplitN(member, ":", 2)[0],
+					Condition: AssetIamCondition{
+						Title:       binding.GetCondition().GetTitle(),
+						Description: binding.GetCondition().GetDescription(),
+						Expression:  binding.GetCondition().GetExpression(),
+					},
+				})
+			}
+		}
+	}
+	return rows, nil
+}
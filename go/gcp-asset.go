@@ -3,7 +3,6 @@ package main
 import (
 	"fmt"
 	"os"
-	"strings"
 	"time"
 
 	"golang.org/x/net/context"
@@ -12,6 +11,8 @@ import (
 	asset "cloud.google.com/go/asset/apiv1"
 	bigquery "cloud.google.com/go/bigquery"
 	assetpb "google.golang.org/genproto/googleapis/cloud/asset/v1"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 var AssetDebugLevel = DebugLevel(ERROR)
@@ -27,6 +28,7 @@ type Asset struct {
 	Action            AssetAction      `bigquery:"-"` //Derived from Deatiled and List DIFF
 	AssetList         []*assetpb.Asset `bigquery:"-"` //Derived from ListAssets method
 	DistinctAssetList []string         `bigquery:"-"` //Derived from Bigquery Distinct Query
+	ReadTime          time.Time        `bigquery:"-"` //Point in time read by ListAssets, zero reads the current state
 }
 
 type AssetResource struct {
@@ -59,10 +61,21 @@ func (a *Asset) GetSchema() (bigquery.Schema, error) {
 //// Supported AssetTypes
 // https://cloud.google.com/asset-inventory/docs/supported-asset-types#searchable_asset_types
 func (a *Asset) CollectAssets(parent string, assetTypes []string) error {
+	// Resource data is required by DiscoveryAsset, other content types are collected by AssetContent
+	assetList, err := listAssets(parent, assetTypes, assetpb.ContentType_RESOURCE, a.ReadTime)
+	if err != nil {
+		return err
+	}
+	a.AssetList = assetList
+	return nil
+}
+
+// listAssets lists the assets under parent as of readTime, a zero readTime lists the current state
+func listAssets(parent string, assetTypes []string, contentType assetpb.ContentType, readTime time.Time) ([]*assetpb.Asset, error) {
 	ctx := context.Background()
 	client, err := asset.NewClient(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	defer client.Close()
 
@@ -71,7 +84,7 @@ func (a *Asset) CollectAssets(parent string, assetTypes []string) error {
 	}
 
 	if AssetDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
-		fmt.Printf("DEBUG: Asset:CollectAssets  Paret = %s \n", parent)
+		fmt.Printf("DEBUG: Asset:CollectAssets  Paret = %s ContentType = %s ReadTime = %s \n", parent, contentType, readTime)
 	}
 
 	//// Example Parent Options
@@ -82,7 +95,11 @@ func (a *Asset) CollectAssets(parent string, assetTypes []string) error {
 	request := &assetpb.ListAssetsRequest{
 		Parent:      parent,
 		AssetTypes:  assetTypes[:],
-		ContentType: assetpb.ContentType_CONTENT_TYPE_UNSPECIFIED,
+		ContentType: contentType,
+	}
+	// ListAssets accepts a read_time up to 35 days in the past
+	if !(readTime.IsZero()) {
+		request.ReadTime = timestamppb.New(readTime)
 	}
 
 	// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/assets/list
@@ -97,34 +114,31 @@ func (a *Asset) CollectAssets(parent string, assetTypes []string) error {
 			if AssetDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
 				fmt.Printf("ERROR: Asset:assetList  %+v \n", err)
 			}
-			return err
+			return nil, err
 		}
 		assetList = append(assetList, asset)
 		if AssetDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
 			fmt.Printf("TRACE: Asset:assetList  %s \n", asset)
 		}
 	}
-	a.AssetList = assetList
-	return nil
+	return assetList, nil
 }
 
 func (a *Asset) ListDistinctAssets(projectID string, datasetID string, assetInventoryTableID string) []string {
 	var queryString = fmt.Sprintf(`SELECT distinct(asset_type) FROM %s.%s.%s order by asset_type`, projectID, datasetID, assetInventoryTableID)
 	results, err := bqExecutQuery(projectID, queryString)
 	if err != nil {
-		fmt.Println("bigquery.NewClient: %v", err)
+		fmt.Printf("bigquery.NewClient: %v\n", err)
 	}
 
 	var assetTableIDs []string
+	var distinctAssetList []string
 	for _, row := range results {
-		//// There is a better way to do this but this works for now
-		// Start Cleanup Needed
-		var _name = row.([]bigquery.Value)[0].(string)
-		_name = strings.Replace(_name, ".", "_", -1)
-		_name = strings.Replace(_name, "/", "_", -1)
-		assetTableIDs = append(assetTableIDs, _name)
-		// STOP Cleanup Needed
+		var assetType = row.([]bigquery.Value)[0].(string)
+		distinctAssetList = append(distinctAssetList, assetType)
+		assetTableIDs = append(assetTableIDs, AssetTableIDFromType(assetType))
 	}
+	a.DistinctAssetList = distinctAssetList
 	return assetTableIDs
 }
 
@@ -187,3 +201,42 @@ func (a *Asset) RefreshInventory(projectID string, datasetID string, datasetRegi
 		fmt.Printf("DEBUG: Asset:RefreshInventory DatasetID: %s TableID: %s \n", datasetID, assetInventoryTableID)
 	}
 }
+
+// inventoryAsset converts an asset returned by the Asset API into a row of the inventory table
+func inventoryAsset(asset *assetpb.Asset) (Asset, error) {
+	// Data is stored as JSON so it can be read back by DiscoveryAsset and JSON_* functions
+	data, err := protojson.Marshal(asset.GetResource().GetData())
+	if err != nil {
+		return Asset{}, fmt.Errorf("protojson.Marshal: %v", err)
+	}
+	return Asset{
+		Name:       asset.GetName(),
+		Asset_type: asset.GetAssetType(),
+		Ancestors:  asset.GetAncestors(),
+		Resource: AssetResource{
+			Version:                asset.GetResource().GetVersion(),
+			Discovery_document_url: asset.GetResource().GetDiscoveryDocumentUri(),
+			Discovery_name:         asset.GetResource().GetDiscoveryName(),
+			Resource_url:           asset.GetResource().GetResourceUrl(),
+			Parent:                 asset.GetResource().GetParent(),
+			Data:                   string(data),
+			Location:               asset.GetResource().GetLocation(),
+		},
+		Update_Time: time.Unix(asset.GetUpdateTime().GetSeconds(), int64(asset.GetUpdateTime().GetNanos())),
+	}, nil
+}
+
+// AssetInventoryTableID returns the table a point in time inventory is written to, tableID_YYYYMMDD for a
+// read time at midnight UTC and tableID_YYYYMMDD_HHMMSS otherwise, so two read times of the same day do not
+// replace each other and the tables can still be queried together with a wildcard table (tableID_*).
+// The current inventory keeps tableID.
+func AssetInventoryTableID(tableID string, readTime time.Time) string {
+	if readTime.IsZero() {
+		return tableID
+	}
+	readTime = readTime.UTC()
+	if readTime.Equal(readTime.Truncate(24 * time.Hour)) {
+		return fmt.Sprintf("%s_%s", tableID, readTime.Format("20060102"))
+	}
+	return fmt.Sprintf("%s_%s", tableID, readTime.Format("20060102_150405"))
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAssetInventoryTableID(t *testing.T) {
+	tests := []struct {
+		name     string
+		readTime string
+		want     string
+	}{
+		{"current inventory", "", "cloudasset_googleapis_com_Asset"},
+		{"date", "2026-10-13T00:00:00Z", "cloudasset_googleapis_com_Asset_20261013"},
+		{"morning", "2026-10-13T09:00:00Z", "cloudasset_googleapis_com_Asset_20261013_090000"},
+		{"evening", "2026-10-13T17:00:00Z", "cloudasset_googleapis_com_Asset_20261013_170000"},
+		{"offset", "2026-10-13T01:30:00+02:00", "cloudasset_googleapis_com_Asset_20261012_233000"},
+		{"midnight in offset", "2026-10-13T02:00:00+02:00", "cloudasset_googleapis_com_Asset_20261013"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var readTime time.Time
+			if tt.readTime != "" {
+				var err error
+				if readTime, err = time.Parse(time.RFC3339, tt.readTime); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if got := AssetInventoryTableID("cloudasset_googleapis_com_Asset", readTime); got != tt.want {
+				t.Errorf("AssetInventoryTableID(%s) = %s, want %s", tt.readTime, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// BigqueryDataset stores BigQuery datasets as returned by the bigquery/v2 REST API, the selfLink
+// (https://bigquery.googleapis.com/bigquery/v2/projects/PROJECT/datasets/DATASET) matches the asset name
+type BigqueryDataset bigqueryapi.Dataset
+
+func init() {
+	RegisterAssetHandler(&BigqueryDataset{})
+}
+
+func (z BigqueryDataset) AssetType() string {
+	return "bigquery.googleapis.com/Dataset"
+}
+func (z BigqueryDataset) AssetTableID() string {
+	return "bigquery_googleapis_com_Dataset"
+}
+
+// https://cloud.google.com/bigquery/docs/reference/rest/v2/datasets/get
+func (z *BigqueryDataset) GetAsset(assetName string) (AssetHandler, error) {
+	bigqueryService, err := sharedBigqueryApiService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //bigquery.googleapis.com/projects/PROJECT/datasets/DATASET
+	nameSplit := strings.Split(AssetRelativeName(assetName), "/")
+	if len(nameSplit) != 4 {
+		return nil, fmt.Errorf("BigqueryDataset:GetAsset %s is not a supported asset name", assetName)
+	}
+
+	asset, err := bigqueryService.Datasets.Get(nameSplit[1], nameSplit[3]).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*BigqueryDataset)(asset), nil
+}
+
+func (z BigqueryDataset) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *BigqueryDataset) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
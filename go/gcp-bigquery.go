@@ -112,9 +112,18 @@ func InferSchema(st interface{}) (bigquery.Schema, error) {
 			if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
 				fmt.Printf("TRACE: InferSchema:PTR %s \n", field)
 			}
+			// A record without any supported nested field is rejected by BigQuery
+			if len(field.Schema) == 0 {
+				continue
+			}
 			fieldSchema = append(fieldSchema, field)
 		case reflect.Slice:
-			fieldSchema = append(fieldSchema, InferFieldSchema(name, value))
+			field := InferFieldSchema(name, value)
+			// Unsupported slices are returned without a type and can not be part of the schema
+			if field.Type == "" || (field.Type == bigquery.RecordFieldType && len(field.Schema) == 0) {
+				continue
+			}
+			fieldSchema = append(fieldSchema, field)
 		default:
 			if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(ERROR).EnumIndex() {
 				fmt.Printf("ERROR: InferSchema %s is of type <%s> and is not currently defined.\n", name, kind)
@@ -158,6 +167,26 @@ func InferFieldSchema(fieldName string, rv reflect.Value) bigquery.FieldSchema {
 			fmt.Printf("TRACE: InferFieldSchema:String %s \n", field)
 		}
 		return field
+	case reflect.Float32, reflect.Float64:
+		field := bigquery.FieldSchema{}
+		field.Name = fieldName
+		field.Type = bigquery.FloatFieldType
+		field.Required = false
+		field.Repeated = true
+		if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
+			fmt.Printf("TRACE: InferFieldSchema:Float %s \n", field)
+		}
+		return field
+	case reflect.Bool:
+		field := bigquery.FieldSchema{}
+		field.Name = fieldName
+		field.Type = bigquery.BooleanFieldType
+		field.Required = false
+		field.Repeated = true
+		if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
+			fmt.Printf("TRACE: InferFieldSchema:Bool %s \n", field)
+		}
+		return field
 	case reflect.Ptr:
 		field := bigquery.FieldSchema{}
 		field.Name = fieldName
@@ -206,6 +235,16 @@ func InferField(fieldName string, rt reflect.Type) bigquery.FieldSchema {
 			fmt.Printf("TRACE: InferField:String %s \n", field)
 		}
 		return field
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		field := bigquery.FieldSchema{}
+		field.Name = fieldName
+		field.Type = bigquery.IntegerFieldType
+		field.Required = false
+		field.Repeated = false
+		if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
+			fmt.Printf("TRACE: InferField:Int %s \n", field)
+		}
+		return field
 	default:
 		if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(ERROR).EnumIndex() {
 			fmt.Printf("ERROR: InferField %s is of type <%s> and is not currently defined.\n", fieldName, kind)
@@ -285,6 +324,12 @@ func InferFields(rt reflect.Type) []bigquery.FieldSchema {
 			switch sliceType {
 			case reflect.String:
 				field.Type = bigquery.StringFieldType
+			case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+				field.Type = bigquery.IntegerFieldType
+			case reflect.Float32, reflect.Float64:
+				field.Type = bigquery.FloatFieldType
+			case reflect.Bool:
+				field.Type = bigquery.BooleanFieldType
 			case reflect.Ptr:
 				field.Type = bigquery.RecordFieldType
 
@@ -292,10 +337,15 @@ func InferFields(rt reflect.Type) []bigquery.FieldSchema {
 				for ii := range nestedFields {
 					field.Schema = append(field.Schema, &nestedFields[ii])
 				}
+				// A record without any supported nested field is rejected by BigQuery
+				if len(field.Schema) == 0 {
+					continue
+				}
 			default:
 				if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(ERROR).EnumIndex() {
 					fmt.Printf("ERROR: InferFields:Slice %s is of type <%s> and is not currently defined. This item was passed from Parent: %s \n", name, sliceType, parent)
 				}
+				continue
 			}
 			if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
 				fmt.Printf("TRACE: InferFields:Slice %s \n", field)
@@ -319,8 +369,11 @@ func InferFields(rt reflect.Type) []bigquery.FieldSchema {
 				if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
 					fmt.Printf("TRACE: InferFields:Ptr %s \n", field)
 				}
+				if len(field.Schema) == 0 {
+					continue
+				}
 				schema = append(schema, field)
-			case reflect.String, reflect.Bool:
+			case reflect.String, reflect.Bool, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
 				schema = append(schema, InferField(name, rtTypeOf))
 			default:
 				if InferSchemaDebugLevel.EnumIndex() >= DebugLevel(ERROR).EnumIndex() {
@@ -423,6 +476,45 @@ func bqTableCreate(projectID string, datasetID string, tableID string, schema bi
 	return nil
 }
 
+// bqTableSchemaUpdate adds the top level fields of schema that are missing from an existing table,
+// BigQuery only allows new fields to be NULLABLE or REPEATED
+func bqTableSchemaUpdate(projectID string, datasetID string, tableID string, schema bigquery.Schema) error {
+	ctx := context.Background()
+	client, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	table := client.Dataset(datasetID).Table(tableID)
+	metadata, err := table.Metadata(ctx)
+	if err != nil {
+		return fmt.Errorf("bigquery.table.Metadata: %v", err)
+	}
+
+	existingFields := map[string]bool{}
+	for _, field := range metadata.Schema {
+		existingFields[strings.ToLower(field.Name)] = true
+	}
+	updatedSchema := metadata.Schema
+	for _, field := range schema {
+		if !(existingFields[strings.ToLower(field.Name)]) {
+			updatedSchema = append(updatedSchema, field)
+			if BigqueryDebugLevel.EnumIndex() >= DebugLevel(INFO).EnumIndex() {
+				fmt.Printf("INFO: bqTable:UPDATE `datasetID: %s tableID: %s` adding field %s \n", datasetID, tableID, field.Name)
+			}
+		}
+	}
+	if len(updatedSchema) == len(metadata.Schema) {
+		return nil
+	}
+
+	if _, err := table.Update(ctx, bigquery.TableMetadataToUpdate{Schema: updatedSchema}, metadata.ETag); err != nil {
+		return fmt.Errorf("bigquery.table.Update: %v", err)
+	}
+	return nil
+}
+
 func bqTableDelete(projectID string, datasetID string, tableID string) error {
 	ctx := context.Background()
 	client, err := bigquery.NewClient(ctx, projectID)
@@ -456,20 +548,9 @@ func bqDatasetAssetInventoryRefresh(projectID string, datasetID string, tableID
 	// Converts returns Asset List to strut that matches schema
 	var assets []Asset
 	for i := range assetList {
-		var asset = Asset{
-			Name:       assetList[i].Name,
-			Asset_type: assetList[i].AssetType,
-			Ancestors:  assetList[i].Ancestors,
-			Resource: AssetResource{
-				Version:                assetList[i].Resource.GetVersion(),
-				Discovery_document_url: assetList[i].Resource.GetDiscoveryDocumentUri(),
-				Discovery_name:         assetList[i].Resource.GetDiscoveryName(),
-				Resource_url:           assetList[i].Resource.GetResourceUrl(),
-				Parent:                 assetList[i].Resource.GetParent(),
-				Data:                   assetList[i].Resource.GetData().String(),
-				Location:               assetList[i].Resource.GetLocation(),
-			},
-			Update_Time: time.Unix(assetList[i].UpdateTime.Seconds, int64(assetList[i].UpdateTime.Nanos)),
+		asset, err := inventoryAsset(assetList[i])
+		if err != nil {
+			return err
 		}
 		assets = append(assets, asset)
 	}
@@ -573,6 +654,9 @@ func bqAssetTypesQueryDistinc(projectID string, datasetID string, assetInventory
 	return assetTypes, nil
 }
 
+// bqQueryAssetCompare matches the inventory with the detailed table on the resource path of the asset name
+// and selfLink, zones/ is compared as locations/ because GKE selfLinks and asset names of zonal clusters do
+// not always use the same collection
 func bqQueryAssetCompare(projectID string, datasetID string, assetInventoryTableID string, assetTableID string, assetType string) ([]Asset, error) {
 	ctx := context.Background()
 	client, err := bigquery.NewClient(ctx, projectID)
@@ -586,7 +670,7 @@ func bqQueryAssetCompare(projectID string, datasetID string, assetInventoryTable
 			WITH assetInventoryTable AS (
 				SELECT
 					name,
-					REGEXP_SUBSTR(name,'projects/.*') as customName,
+					REPLACE(IFNULL(REGEXP_SUBSTR(name,'(?:projects|locations)/.*'), name), '/zones/', '/locations/') as customName,
 					update_time
 				from %s.%s.%s
 				where asset_type = '%s'
@@ -594,7 +678,7 @@ func bqQueryAssetCompare(projectID string, datasetID string, assetInventoryTable
 			assetTable AS (
 				SELECT
 					selfLink,
-					REGEXP_SUBSTR(selfLink,'projects/.*') as customName,
+					REPLACE(IFNULL(REGEXP_SUBSTR(selfLink,'(?:projects|locations)/.*'), selfLink), '/zones/', '/locations/') as customName,
 					updatedTimestamp
 				from %s.%s.%s
 			)
@@ -696,6 +780,54 @@ func bqExecutQuery(projectID string, queryString string) ([]bigquery.Value, erro
 	return rows, nil
 }
 
+// bqAssetSelfLinks returns the SelfLink of the rows of tableID that belong to assetName, rows are matched on
+// the same key as bqQueryAssetCompare so it works for handlers keeping the API selfLink
+func bqAssetSelfLinks(projectID string, datasetID string, tableID string, assetName string) ([]string, error) {
+	ctx := context.Background()
+	client, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("bigquery.NewClient: %v", err)
+	}
+
+	defer client.Close()
+	var queryString = fmt.Sprintf(`
+		SELECT SelfLink
+		FROM %s.%s.%s
+		WHERE REPLACE(IFNULL(REGEXP_SUBSTR(SelfLink,'(?:projects|locations)/.*'), SelfLink), '/zones/', '/locations/') =
+			REPLACE(IFNULL(REGEXP_SUBSTR(@name,'(?:projects|locations)/.*'), @name), '/zones/', '/locations/')`,
+		projectID, datasetID, tableID)
+
+	if BigqueryDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: bqAssetSelfLinks:QUERY `%s` name: %s \n", queryString, assetName)
+	}
+	query := client.Query(queryString)
+	query.Parameters = []bigquery.QueryParameter{{Name: "name", Value: assetName}}
+	query.DisableQueryCache = true
+
+	result, err := query.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var selfLinks []string
+	for {
+		var row struct {
+			SelfLink bigquery.NullString
+		}
+		err := result.Next(&row)
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("bigquery.query.Iterator: %v", err)
+		}
+		if row.SelfLink.Valid {
+			selfLinks = append(selfLinks, row.SelfLink.StringVal)
+		}
+	}
+	return selfLinks, nil
+}
+
 func bqAssetDelete(projectID string, datasetID string, tableID string, selfLink string) error {
 	ctx := context.Background()
 	client, err := bigquery.NewClient(ctx, projectID)
@@ -724,3 +856,106 @@ func bqAssetDelete(projectID string, datasetID string, tableID string, selfLink
 	_ = it // TODO: iterate using Next or iterator.Pager.
 	return nil
 }
+
+// bqTableReplaceRows replaces the content of tableID with rows, the table is created when it does not exist.
+// Rows are loaded as newline delimited JSON so nested and repeated fields do not need a ValueSaver, every
+// JSON field of a row must be a column of schema.
+func bqTableReplaceRows(projectID string, datasetID string, tableID string, schema bigquery.Schema, rows []interface{}) error {
+	ctx := context.Background()
+	client, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	var rowsJSON strings.Builder
+	for _, row := range rows {
+		rowJSON, err := json.Marshal(row)
+		if err != nil {
+			return fmt.Errorf("bqTableReplaceRows:json.Marshal: %v", err)
+		}
+		rowsJSON.Write(rowJSON)
+		rowsJSON.WriteString("\n")
+	}
+
+	bqReaderSource := bigquery.NewReaderSource(strings.NewReader(rowsJSON.String()))
+	bqReaderSource.SourceFormat = bigquery.JSON
+	bqReaderSource.Schema = schema
+
+	table := client.Dataset(datasetID).Table(tableID)
+	loader := table.LoaderFrom(bqReaderSource)
+	loader.CreateDisposition = bigquery.CreateIfNeeded
+	loader.WriteDisposition = bigquery.WriteTruncate
+
+	job, err := loader.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("bigquery.Loader.Run: %v", err)
+	}
+
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("bigquery.Job.Wait: %v", err)
+	}
+	if status.Err() != nil {
+		return fmt.Errorf("bigquery.Job.Status: %v", status.Err())
+	}
+
+	if BigqueryDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: bqTableReplaceRows `datasetID: %s tableID: %s` rows: %d \n", datasetID, tableID, len(rows))
+	}
+	return nil
+}
+
+// bqAssetInsert loads a single asset into its detailed table and stamps it with UpdatedTimestamp
+func bqAssetInsert(projectID string, datasetID string, tableID string, schema bigquery.Schema, asset interface{}) error {
+	ctx := context.Background()
+	client, err := bigquery.NewClient(ctx, projectID)
+	if err != nil {
+		return fmt.Errorf("bigquery.NewClient: %v", err)
+	}
+	defer client.Close()
+
+	assetJSON, err := json.Marshal(asset)
+	if err != nil {
+		return fmt.Errorf("bqAssetInsert:json.Marshal: %v", err)
+	}
+
+	var assetRow map[string]json.RawMessage
+	if err := json.Unmarshal(assetJSON, &assetRow); err != nil {
+		return fmt.Errorf("bqAssetInsert:json.Unmarshal: %v", err)
+	}
+	updatedTimestamp, _ := json.Marshal(time.Now().UTC())
+	assetRow["UpdatedTimestamp"] = updatedTimestamp
+
+	assetJSON, err = json.Marshal(assetRow)
+	if err != nil {
+		return fmt.Errorf("bqAssetInsert:json.Marshal: %v", err)
+	}
+
+	bqReaderSource := bigquery.NewReaderSource(strings.NewReader(string(assetJSON)))
+	bqReaderSource.SourceFormat = bigquery.JSON
+	bqReaderSource.Schema = schema
+	bqReaderSource.IgnoreUnknownValues = true
+
+	table := client.Dataset(datasetID).Table(tableID)
+	loader := table.LoaderFrom(bqReaderSource)
+	loader.CreateDisposition = bigquery.CreateNever
+
+	job, err := loader.Run(ctx)
+	if err != nil {
+		return fmt.Errorf("bigquery.Loader.Run: %v", err)
+	}
+
+	status, err := job.Wait(ctx)
+	if err != nil {
+		return fmt.Errorf("bigquery.Job.Wait: %v", err)
+	}
+	if status.Err() != nil {
+		return fmt.Errorf("bigquery.Job.Status: %v", status.Err())
+	}
+
+	if BigqueryDebugLevel.EnumIndex() >= DebugLevel(TRACE).EnumIndex() {
+		fmt.Printf("TRACE: bqAssetInsert:INSERT `datasetID: %s tableID: %s` %s \n", datasetID, tableID, string(assetJSON))
+	}
+	return nil
+}
@@ -0,0 +1,21 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	bigqueryapi "google.golang.org/api/bigquery/v2"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpBigqueryApiService() (*bigqueryapi.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, bigqueryapi.CloudPlatformReadOnlyScope)
+	if err != nil {
+		return nil, err
+	}
+	return bigqueryapi.New(client)
+}
+
+// sharedBigqueryApiService returns a bigquery/v2 REST service that is created once and reused by every handler,
+// it is only used to read datasets, the inventory tables are written with the cloud.google.com/go/bigquery client
+var sharedBigqueryApiService = cachedService(gcpBigqueryApiService)
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudfunctions/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// FunctionsCloudFunction is the function returned by the Cloud Functions API v1
+type FunctionsCloudFunction cloudfunctions.CloudFunction
+
+// CloudFunction stores Cloud Functions.
+// InvokerMembers are the members of the roles/cloudfunctions.invoker binding of the function IAM policy,
+// PubliclyInvokable is NULL when the IAM policy can not be read.
+type CloudFunction struct {
+	*FunctionsCloudFunction
+	SelfLink          string   `json:"selfLink"`
+	InvokerMembers    []string `json:"invokerMembers"`
+	PubliclyInvokable *bool    `json:"publiclyInvokable"`
+}
+
+func init() {
+	RegisterAssetHandler(&CloudFunction{})
+}
+
+func (z CloudFunction) AssetType() string {
+	return "cloudfunctions.googleapis.com/CloudFunction"
+}
+func (z CloudFunction) AssetTableID() string {
+	return "cloudfunctions_googleapis_com_CloudFunction"
+}
+
+// https://cloud.google.com/functions/docs/reference/rest/v1/projects.locations.functions/get
+// https://cloud.google.com/functions/docs/reference/rest/v1/projects.locations.functions/getIamPolicy
+func (z *CloudFunction) GetAsset(assetName string) (AssetHandler, error) {
+	functionsService, err := sharedCloudFunctionsService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudfunctions.googleapis.com/projects/PROJECT/locations/LOCATION/functions/FUNCTION
+	functionName := AssetRelativeName(assetName)
+
+	asset, err := functionsService.Projects.Locations.Functions.Get(functionName).Do()
+	if err != nil {
+		return nil, err
+	}
+	function := &CloudFunction{FunctionsCloudFunction: (*FunctionsCloudFunction)(asset), SelfLink: assetName}
+
+	policy, err := functionsService.Projects.Locations.Functions.GetIamPolicy(functionName).Do()
+	if err != nil {
+		// The function is still recorded when the IAM policy can not be read
+		if ServerlessDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: CloudFunction:GetAsset:GetIamPolicy %s: %v \n", functionName, err)
+		}
+	} else {
+		for _, binding := range policy.Bindings {
+			if binding.Role == "roles/cloudfunctions.invoker" {
+				function.InvokerMembers = append(function.InvokerMembers, binding.Members...)
+			}
+		}
+	}
+	function.PubliclyInvokable = publiclyInvokable(function.InvokerMembers, err == nil)
+
+	return function, nil
+}
+
+func (z CloudFunction) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(FunctionsCloudFunction{})
+	if err != nil {
+		return nil, err
+	}
+
+	iamSchema, err := InferSchema(struct {
+		InvokerMembers    []string
+		PubliclyInvokable bool
+	}{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, iamSchema...), nil
+}
+
+func (z *CloudFunction) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.FunctionsCloudFunction == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
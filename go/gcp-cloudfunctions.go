@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudfunctions/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpCloudFunctionsService() (*cloudfunctions.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, cloudfunctions.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return cloudfunctions.New(client)
+}
+
+// sharedCloudFunctionsService returns a cloudfunctions service that is created once and reused by every handler
+var sharedCloudFunctionsService = cachedService(gcpCloudFunctionsService)
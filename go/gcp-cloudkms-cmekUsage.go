@@ -0,0 +1,60 @@
+package main
+
+// view_cloudkms_CmekUsage has one row per crypto key and Disk, Bucket or BigQuery dataset encrypted with it.
+// Keys without any referencing resource have a NULL Resource, resources referencing a key that is not part
+// of the inventory have a NULL CryptoKey. Disks reference a key version, references are reduced to the key.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_cloudkms_CmekUsage",
+		AssetTableIDs: []string{
+			(CryptoKey{}).AssetTableID(),
+			(Disk{}).AssetTableID(),
+			(Bucket{}).AssetTableID(),
+			(BigqueryDataset{}).AssetTableID(),
+		},
+		Query: `
+			WITH cmekUsage AS (
+				SELECT
+					'compute.googleapis.com/Disk' AS AssetType,
+					disk.SelfLink,
+					disk.DiskEncryptionKey.KmsKeyName
+				FROM ${dataset}.compute_googleapis_com_Disk AS disk
+				WHERE disk.DiskEncryptionKey.KmsKeyName IS NOT NULL
+				UNION ALL
+				SELECT
+					'storage.googleapis.com/Bucket' AS AssetType,
+					bucket.SelfLink,
+					bucket.Encryption.DefaultKmsKeyName AS KmsKeyName
+				FROM ${dataset}.storage_googleapis_com_Bucket AS bucket
+				WHERE bucket.Encryption.DefaultKmsKeyName IS NOT NULL
+				UNION ALL
+				SELECT
+					'bigquery.googleapis.com/Dataset' AS AssetType,
+					bigqueryDataset.SelfLink,
+					bigqueryDataset.DefaultEncryptionConfiguration.KmsKeyName
+				FROM ${dataset}.bigquery_googleapis_com_Dataset AS bigqueryDataset
+				WHERE bigqueryDataset.DefaultEncryptionConfiguration.KmsKeyName IS NOT NULL
+			),
+			cmekUsageKey AS (
+				SELECT
+					cmekUsage.*,
+					REGEXP_EXTRACT(cmekUsage.KmsKeyName, r'(projects/[^/]+/locations/[^/]+/keyRings/[^/]+/cryptoKeys/[^/]+)') AS CryptoKeyName
+				FROM cmekUsage
+			)
+			SELECT
+				COALESCE(cryptoKey.Name, cmekUsageKey.CryptoKeyName) AS CryptoKeyName,
+				cryptoKey.SelfLink AS CryptoKey,
+				cryptoKey.Purpose,
+				cryptoKey.RotationPeriod,
+				cryptoKey.RotationPeriod IS NOT NULL AS RotationEnabled,
+				SAFE_CAST(cryptoKey.NextRotationTime AS TIMESTAMP) AS NextRotationTime,
+				cryptoKey.Primary.State AS PrimaryVersionState,
+				IFNULL(cryptoKey.Primary.ProtectionLevel, cryptoKey.VersionTemplate.ProtectionLevel) AS ProtectionLevel,
+				cmekUsageKey.AssetType,
+				cmekUsageKey.SelfLink AS Resource,
+				cmekUsageKey.KmsKeyName
+			FROM cmekUsageKey
+			FULL OUTER JOIN ${dataset}.cloudkms_googleapis_com_CryptoKey AS cryptoKey
+				ON cryptoKey.Name = cmekUsageKey.CryptoKeyName`,
+	})
+}
@@ -0,0 +1,70 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudkms/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// KmsCryptoKey is the crypto key returned by the Cloud KMS API. Primary holds the state and protection level
+// of the primary version, it is only set for symmetric ENCRYPT_DECRYPT keys.
+type KmsCryptoKey cloudkms.CryptoKey
+
+// CryptoKey stores Cloud KMS crypto keys.
+// Labels replaces the label map of the API with a repeated key/value record.
+type CryptoKey struct {
+	*KmsCryptoKey
+	SelfLink string           `json:"selfLink"`
+	Labels   []*ResourceLabel `json:"labels"`
+}
+
+func init() {
+	RegisterAssetHandler(&CryptoKey{})
+}
+
+func (z CryptoKey) AssetType() string {
+	return "cloudkms.googleapis.com/CryptoKey"
+}
+func (z CryptoKey) AssetTableID() string {
+	return "cloudkms_googleapis_com_CryptoKey"
+}
+
+// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings.cryptoKeys/get
+func (z *CryptoKey) GetAsset(assetName string) (AssetHandler, error) {
+	kmsService, err := sharedCloudKmsService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudkms.googleapis.com/projects/PROJECT/locations/LOCATION/keyRings/KEY_RING/cryptoKeys/KEY
+	asset, err := kmsService.Projects.Locations.KeyRings.CryptoKeys.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &CryptoKey{
+		KmsCryptoKey: (*KmsCryptoKey)(asset),
+		SelfLink:     assetName,
+		Labels:       resourceLabels(asset.Labels),
+	}, nil
+}
+
+func (z CryptoKey) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(KmsCryptoKey{})
+	if err != nil {
+		return nil, err
+	}
+	labelSchema, err := InferSchema(struct{ Labels []*ResourceLabel }{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, labelSchema...), nil
+}
+
+func (z *CryptoKey) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.KmsCryptoKey == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
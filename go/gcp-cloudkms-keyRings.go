@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudkms/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// KmsKeyRing is the Cloud KMS key ring returned by the API
+type KmsKeyRing cloudkms.KeyRing
+
+// KeyRing stores Cloud KMS key rings
+type KeyRing struct {
+	*KmsKeyRing
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&KeyRing{})
+}
+
+func (z KeyRing) AssetType() string {
+	return "cloudkms.googleapis.com/KeyRing"
+}
+func (z KeyRing) AssetTableID() string {
+	return "cloudkms_googleapis_com_KeyRing"
+}
+
+// https://cloud.google.com/kms/docs/reference/rest/v1/projects.locations.keyRings/get
+func (z *KeyRing) GetAsset(assetName string) (AssetHandler, error) {
+	kmsService, err := sharedCloudKmsService()
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := kmsService.Projects.Locations.KeyRings.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &KeyRing{KmsKeyRing: (*KmsKeyRing)(asset), SelfLink: assetName}, nil
+}
+
+func (z KeyRing) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(KmsKeyRing{})
+}
+
+func (z *KeyRing) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.KmsKeyRing == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
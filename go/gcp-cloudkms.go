@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudkms/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpCloudKmsService() (*cloudkms.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, cloudkms.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return cloudkms.New(client)
+}
+
+// sharedCloudKmsService returns a cloudkms service that is created once and reused by every handler
+var sharedCloudKmsService = cachedService(gcpCloudKmsService)
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v3"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ResourceManagerFolder is the folder returned by the Resource Manager API
+type ResourceManagerFolder cloudresourcemanager.Folder
+
+// Folder stores Resource Manager folders
+type Folder struct {
+	*ResourceManagerFolder
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&Folder{})
+}
+
+func (z Folder) AssetType() string {
+	return "cloudresourcemanager.googleapis.com/Folder"
+}
+func (z Folder) AssetTableID() string {
+	return "cloudresourcemanager_googleapis_com_Folder"
+}
+
+// https://cloud.google.com/resource-manager/reference/rest/v3/folders/get
+func (z *Folder) GetAsset(assetName string) (AssetHandler, error) {
+	resourceManagerService, err := sharedCloudResourceManagerService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudresourcemanager.googleapis.com/folders/FOLDER_NUMBER
+	asset, err := resourceManagerService.Folders.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &Folder{ResourceManagerFolder: (*ResourceManagerFolder)(asset), SelfLink: assetName}, nil
+}
+
+func (z Folder) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(ResourceManagerFolder{})
+}
+
+func (z *Folder) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.ResourceManagerFolder == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+// derived_cloudresourcemanager_Hierarchy flattens the ancestors of every inventory asset, one row per asset
+// and ancestor. Depth 0 is the root of the hierarchy (usually the organization) and increases towards the
+// asset, DisplayName falls back to the ancestor name when the project, folder or organization is not part
+// of the inventory. Path joins the display names from the root down to the ancestor so inventory can be
+// grouped by folder path.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "derived_cloudresourcemanager_Hierarchy",
+		AssetTableIDs: []string{
+			(Project{}).AssetTableID(),
+			(Folder{}).AssetTableID(),
+			(Organization{}).AssetTableID(),
+		},
+		Materialized: true,
+		Query: `
+			WITH displayName AS (
+				SELECT project.SelfLink, IFNULL(project.DisplayName, project.ProjectId) AS DisplayName, project.State
+				FROM ${dataset}.cloudresourcemanager_googleapis_com_Project AS project
+				UNION ALL
+				SELECT folder.SelfLink, folder.DisplayName, folder.State
+				FROM ${dataset}.cloudresourcemanager_googleapis_com_Folder AS folder
+				UNION ALL
+				SELECT organization.SelfLink, organization.DisplayName, organization.State
+				FROM ${dataset}.cloudresourcemanager_googleapis_com_Organization AS organization
+			),
+			ancestor AS (
+				SELECT
+					asset.Name,
+					asset.Asset_type AS AssetType,
+					ancestor AS Ancestor,
+					SPLIT(ancestor, '/')[OFFSET(0)] AS AncestorType,
+					ARRAY_LENGTH(asset.Ancestors) - 1 - ancestorOffset AS Depth
+				FROM ${dataset}.${asset_inventory_table} AS asset,
+					UNNEST(asset.Ancestors) AS ancestor WITH OFFSET AS ancestorOffset
+			)
+			SELECT
+				ancestor.*,
+				IFNULL(displayName.DisplayName, ancestor.Ancestor) AS DisplayName,
+				displayName.State,
+				STRING_AGG(IFNULL(displayName.DisplayName, ancestor.Ancestor), '/') OVER (
+					PARTITION BY ancestor.Name ORDER BY ancestor.Depth
+					ROWS BETWEEN UNBOUNDED PRECEDING AND CURRENT ROW) AS Path
+			FROM ancestor
+			LEFT JOIN displayName
+				ON displayName.SelfLink = CONCAT('//cloudresourcemanager.googleapis.com/', ancestor.Ancestor)`,
+	})
+}
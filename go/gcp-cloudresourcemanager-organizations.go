@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v3"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ResourceManagerOrganization is the organization returned by the Resource Manager API
+type ResourceManagerOrganization cloudresourcemanager.Organization
+
+// Organization stores Resource Manager organizations
+type Organization struct {
+	*ResourceManagerOrganization
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&Organization{})
+}
+
+func (z Organization) AssetType() string {
+	return "cloudresourcemanager.googleapis.com/Organization"
+}
+func (z Organization) AssetTableID() string {
+	return "cloudresourcemanager_googleapis_com_Organization"
+}
+
+// https://cloud.google.com/resource-manager/reference/rest/v3/organizations/get
+func (z *Organization) GetAsset(assetName string) (AssetHandler, error) {
+	resourceManagerService, err := sharedCloudResourceManagerService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudresourcemanager.googleapis.com/organizations/ORGANIZATION_ID
+	asset, err := resourceManagerService.Organizations.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &Organization{ResourceManagerOrganization: (*ResourceManagerOrganization)(asset), SelfLink: assetName}, nil
+}
+
+func (z Organization) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(ResourceManagerOrganization{})
+}
+
+func (z *Organization) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.ResourceManagerOrganization == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/cloudresourcemanager/v3"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ResourceManagerProject is the project returned by the Resource Manager API
+type ResourceManagerProject cloudresourcemanager.Project
+
+// Project stores Resource Manager projects.
+// Labels replaces the label map of the API with a repeated key/value record.
+type Project struct {
+	*ResourceManagerProject
+	SelfLink string           `json:"selfLink"`
+	Labels   []*ResourceLabel `json:"labels"`
+}
+
+func init() {
+	RegisterAssetHandler(&Project{})
+}
+
+func (z Project) AssetType() string {
+	return "cloudresourcemanager.googleapis.com/Project"
+}
+func (z Project) AssetTableID() string {
+	return "cloudresourcemanager_googleapis_com_Project"
+}
+
+// https://cloud.google.com/resource-manager/reference/rest/v3/projects/get
+func (z *Project) GetAsset(assetName string) (AssetHandler, error) {
+	resourceManagerService, err := sharedCloudResourceManagerService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudresourcemanager.googleapis.com/projects/PROJECT_NUMBER
+	asset, err := resourceManagerService.Projects.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &Project{
+		ResourceManagerProject: (*ResourceManagerProject)(asset),
+		SelfLink:               assetName,
+		Labels:                 resourceLabels(asset.Labels),
+	}, nil
+}
+
+func (z Project) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(ResourceManagerProject{})
+	if err != nil {
+		return nil, err
+	}
+	labelSchema, err := InferSchema(struct{ Labels []*ResourceLabel }{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, labelSchema...), nil
+}
+
+func (z *Project) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.ResourceManagerProject == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"sort"
+
+	"golang.org/x/net/context"
+	"google.golang.org/api/cloudresourcemanager/v3"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpCloudResourceManagerService() (*cloudresourcemanager.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, cloudresourcemanager.CloudPlatformReadOnlyScope)
+	if err != nil {
+		return nil, err
+	}
+	return cloudresourcemanager.New(client)
+}
+
+// sharedCloudResourceManagerService returns a cloudresourcemanager service that is created once and reused by every handler
+var sharedCloudResourceManagerService = cachedService(gcpCloudResourceManagerService)
+
+// ResourceLabel is a single key/value pair of a label map, maps are not supported by InferSchema so labels
+// are stored as a repeated record
+type ResourceLabel struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+// resourceLabels converts a label map into ResourceLabel rows sorted by key
+func resourceLabels(labels map[string]string) []*ResourceLabel {
+	keys := make([]string, 0, len(labels))
+	for key := range labels {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	resourceLabels := make([]*ResourceLabel, 0, len(keys))
+	for _, key := range keys {
+		resourceLabels = append(resourceLabels, &ResourceLabel{Key: key, Value: labels[key]})
+	}
+	return resourceLabels
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type Autoscaler compute.Autoscaler
+
+func init() {
+	RegisterAssetHandler(&Autoscaler{})
+}
+
+func (z Autoscaler) AssetType() string {
+	return "compute.googleapis.com/Autoscaler"
+}
+func (z Autoscaler) AssetTableID() string {
+	return "compute_googleapis_com_Autoscaler"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/autoscalers/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionAutoscalers/get
+func (z *Autoscaler) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.Autoscaler
+	if name.Zone != "" {
+		asset, err = computeService.Autoscalers.Get(name.Project, name.Zone, name.Resource).Do()
+	} else if name.Region != "" {
+		asset, err = computeService.RegionAutoscalers.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		return nil, fmt.Errorf("Autoscaler:GetAsset %s is not a supported asset name", assetName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*Autoscaler)(asset), nil
+}
+
+func (z Autoscaler) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Autoscaler) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// BackendService covers both global (backendServices) and regional (regionBackendServices) backend services,
+// the nested backends, health check links, IAP and CDN policy are stored as records of the same table
+type BackendService compute.BackendService
+
+func init() {
+	RegisterAssetHandler(&BackendService{})
+}
+
+func (z BackendService) AssetType() string {
+	return "compute.googleapis.com/BackendService"
+}
+func (z BackendService) AssetTableID() string {
+	return "compute_googleapis_com_BackendService"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/backendServices/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionBackendServices/get
+func (z *BackendService) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.BackendService
+	if name.Region != "" {
+		asset, err = computeService.RegionBackendServices.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.BackendServices.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*BackendService)(asset), nil
+}
+
+func (z BackendService) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *BackendService) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
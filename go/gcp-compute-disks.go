@@ -0,0 +1,58 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// Disk covers both zonal (disks) and regional (regionDisks) persistent disks
+type Disk compute.Disk
+
+func init() {
+	RegisterAssetHandler(&Disk{})
+}
+
+func (z Disk) AssetType() string {
+	return "compute.googleapis.com/Disk"
+}
+func (z Disk) AssetTableID() string {
+	return "compute_googleapis_com_Disk"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/disks/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionDisks/get
+func (z *Disk) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.Disk
+	if name.Zone != "" {
+		asset, err = computeService.Disks.Get(name.Project, name.Zone, name.Resource).Do()
+	} else if name.Region != "" {
+		asset, err = computeService.RegionDisks.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		return nil, fmt.Errorf("Disk:GetAsset %s is not a supported asset name", assetName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*Disk)(asset), nil
+}
+
+func (z Disk) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Disk) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
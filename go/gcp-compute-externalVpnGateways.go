@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ExternalVpnGateway is the only global resource of the hybrid connectivity handlers
+type ExternalVpnGateway compute.ExternalVpnGateway
+
+func init() {
+	RegisterAssetHandler(&ExternalVpnGateway{})
+}
+
+func (z ExternalVpnGateway) AssetType() string {
+	return "compute.googleapis.com/ExternalVpnGateway"
+}
+func (z ExternalVpnGateway) AssetTableID() string {
+	return "compute_googleapis_com_ExternalVpnGateway"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/externalVpnGateways/get
+func (z *ExternalVpnGateway) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.ExternalVpnGateways.Get(name.Project, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*ExternalVpnGateway)(asset), nil
+}
+
+func (z ExternalVpnGateway) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *ExternalVpnGateway) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// FirewallPolicy covers hierarchical firewall policies attached to organizations and folders
+// as well as global and regional network firewall policies attached to networks
+type FirewallPolicy compute.FirewallPolicy
+
+func init() {
+	RegisterAssetHandler(&FirewallPolicy{})
+}
+
+func (z FirewallPolicy) AssetType() string {
+	return "compute.googleapis.com/FirewallPolicy"
+}
+func (z FirewallPolicy) AssetTableID() string {
+	return "compute_googleapis_com_FirewallPolicy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/firewallPolicies/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/networkFirewallPolicies/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionNetworkFirewallPolicies/get
+func (z *FirewallPolicy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.FirewallPolicy
+	if name.Region != "" {
+		asset, err = computeService.RegionNetworkFirewallPolicies.Get(name.Project, name.Region, name.Resource).Do()
+	} else if name.Project != "" {
+		asset, err = computeService.NetworkFirewallPolicies.Get(name.Project, name.Resource).Do()
+	} else {
+		asset, err = computeService.FirewallPolicies.Get(name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*FirewallPolicy)(asset), nil
+}
+
+func (z FirewallPolicy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *FirewallPolicy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,79 @@
+package main
+
+// derived_compute_FirewallRule expands every firewall rule and firewall policy rule into one row per
+// (ip range, protocol, port, target). IpRange is the source range of INGRESS rules and the destination
+// range of EGRESS rules, a NULL Port means every port and a TargetType of ALL means every instance.
+//
+//	SELECT * FROM derived_compute_FirewallRule
+//	WHERE Direction = 'INGRESS' AND Action = 'ALLOW' AND NOT Disabled AND IpRange = '0.0.0.0/0'
+//		AND Protocol IN ('tcp', 'all') AND (Port IS NULL OR 22 BETWEEN PortStart AND PortEnd)
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "derived_compute_FirewallRule",
+		AssetTableIDs: []string{
+			(Firewall{}).AssetTableID(),
+			(FirewallPolicy{}).AssetTableID(),
+		},
+		Materialized: true,
+		Query: `
+			WITH firewallRule AS (
+				SELECT
+					'Firewall' AS RuleType,
+					firewall.SelfLink AS FirewallPolicy,
+					firewall.Name AS RuleName,
+					firewall.Network AS AttachmentTarget,
+					firewall.Network,
+					firewall.Direction,
+					firewall.Priority,
+					IFNULL(firewall.Disabled, FALSE) AS Disabled,
+					rule.Action,
+					rule.Protocol,
+					port AS Port,
+					ipRange AS IpRange,
+					IFNULL(target.TargetType, 'ALL') AS TargetType,
+					target.Target
+				FROM ${dataset}.compute_googleapis_com_Firewall AS firewall,
+					UNNEST(ARRAY_CONCAT(
+						ARRAY(SELECT AS STRUCT 'ALLOW' AS Action, LOWER(allowed.IPProtocol) AS Protocol, allowed.Ports FROM UNNEST(firewall.Allowed) AS allowed),
+						ARRAY(SELECT AS STRUCT 'DENY' AS Action, LOWER(denied.IPProtocol) AS Protocol, denied.Ports FROM UNNEST(firewall.Denied) AS denied)
+					)) AS rule
+				LEFT JOIN UNNEST(rule.Ports) AS port
+				LEFT JOIN UNNEST(IF(firewall.Direction = 'EGRESS', firewall.DestinationRanges, firewall.SourceRanges)) AS ipRange
+				LEFT JOIN UNNEST(ARRAY_CONCAT(
+					ARRAY(SELECT AS STRUCT 'TAG' AS TargetType, tag AS Target FROM UNNEST(firewall.TargetTags) AS tag),
+					ARRAY(SELECT AS STRUCT 'SERVICE_ACCOUNT' AS TargetType, serviceAccount AS Target FROM UNNEST(firewall.TargetServiceAccounts) AS serviceAccount)
+				)) AS target
+				UNION ALL
+				SELECT
+					'FirewallPolicy' AS RuleType,
+					firewallPolicy.SelfLink AS FirewallPolicy,
+					IFNULL(rule.RuleName, CAST(rule.Priority AS STRING)) AS RuleName,
+					association.AttachmentTarget,
+					IF(association.AttachmentTarget LIKE '%/networks/%', association.AttachmentTarget, NULL) AS Network,
+					rule.Direction,
+					rule.Priority,
+					IFNULL(rule.Disabled, FALSE) AS Disabled,
+					UPPER(rule.Action) AS Action,
+					LOWER(layer4Config.IpProtocol) AS Protocol,
+					port AS Port,
+					ipRange AS IpRange,
+					IFNULL(target.TargetType, 'ALL') AS TargetType,
+					target.Target
+				FROM ${dataset}.compute_googleapis_com_FirewallPolicy AS firewallPolicy,
+					UNNEST(firewallPolicy.Rules) AS rule
+				LEFT JOIN UNNEST(firewallPolicy.Associations) AS association
+				LEFT JOIN UNNEST(rule.Match.Layer4Configs) AS layer4Config
+				LEFT JOIN UNNEST(layer4Config.Ports) AS port
+				LEFT JOIN UNNEST(IF(rule.Direction = 'EGRESS', rule.Match.DestIpRanges, rule.Match.SrcIpRanges)) AS ipRange
+				LEFT JOIN UNNEST(ARRAY_CONCAT(
+					ARRAY(SELECT AS STRUCT 'SECURE_TAG' AS TargetType, secureTag.Name AS Target FROM UNNEST(rule.TargetSecureTags) AS secureTag),
+					ARRAY(SELECT AS STRUCT 'SERVICE_ACCOUNT' AS TargetType, serviceAccount AS Target FROM UNNEST(rule.TargetServiceAccounts) AS serviceAccount)
+				)) AS target
+			)
+			SELECT
+				*,
+				CAST(SPLIT(Port, '-')[SAFE_OFFSET(0)] AS INT64) AS PortStart,
+				CAST(IFNULL(SPLIT(Port, '-')[SAFE_OFFSET(1)], SPLIT(Port, '-')[SAFE_OFFSET(0)]) AS INT64) AS PortEnd
+			FROM firewallRule`,
+	})
+}
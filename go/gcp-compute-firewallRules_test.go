@@ -0,0 +1,38 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+// The rule expansion itself runs in BigQuery, the test checks the derived table reads both rule sources of the
+// dataset and keeps the columns documented for the firewall queries
+func TestFirewallRuleView(t *testing.T) {
+	view, exist := lookupAssetView("derived_compute_FirewallRule")
+	if !(exist) {
+		t.Fatal("derived_compute_FirewallRule is not registered")
+	}
+	if !(view.Materialized) {
+		t.Error("derived_compute_FirewallRule is expected to be a table")
+	}
+
+	query := view.Expand("my-project", "my_dataset", viewTestParameters)
+	for _, want := range []string{
+		"my-project.my_dataset.compute_googleapis_com_Firewall AS firewall",
+		"my-project.my_dataset.compute_googleapis_com_FirewallPolicy AS firewallPolicy",
+		// INGRESS rules are expanded on their source ranges and EGRESS rules on their destination ranges
+		"IF(firewall.Direction = 'EGRESS', firewall.DestinationRanges, firewall.SourceRanges)",
+		"IF(rule.Direction = 'EGRESS', rule.Match.DestIpRanges, rule.Match.SrcIpRanges)",
+		"AS PortStart",
+		"AS PortEnd",
+	} {
+		if !(strings.Contains(query, want)) {
+			t.Errorf("query does not contain %q", want)
+		}
+	}
+	for _, column := range []string{"RuleType", "RuleName", "Direction", "Action", "Protocol", "Port", "IpRange", "TargetType", "Target"} {
+		if !(strings.Contains(query, " AS "+column)) && !(strings.Contains(query, "."+column+",")) {
+			t.Errorf("query does not select %s", column)
+		}
+	}
+}
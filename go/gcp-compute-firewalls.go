@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type Firewall compute.Firewall
+
+func init() {
+	RegisterAssetHandler(&Firewall{})
+}
+
+func (z Firewall) AssetType() string {
+	return "compute.googleapis.com/Firewall"
+}
+func (z Firewall) AssetTableID() string {
+	return "compute_googleapis_com_Firewall"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/firewalls/get
+func (z *Firewall) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.Firewalls.Get(name.Project, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*Firewall)(asset), nil
+}
+
+func (z Firewall) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Firewall) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
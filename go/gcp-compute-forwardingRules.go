@@ -1,12 +1,9 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
 	"strings"
 
-	"golang.org/x/net/context"
 	"google.golang.org/api/compute/v1"
 
 	"cloud.google.com/go/bigquery"
@@ -14,6 +11,10 @@ import (
 
 type ForwardingRule compute.ForwardingRule
 
+func init() {
+	RegisterAssetHandler(&ForwardingRule{})
+}
+
 func (z ForwardingRule) AssetType() string {
 	return "compute.googleapis.com/ForwardingRule"
 }
@@ -21,32 +22,56 @@ func (z ForwardingRule) AssetTableID() string {
 	return "compute_googleapis_com_ForwardingRule"
 }
 
+// https://cloud.google.com/compute/docs/reference/rest/v1/globalForwardingRules/get
 // https://cloud.google.com/compute/docs/reference/rest/v1/forwardingRules/get
-func (z *ForwardingRule) GetAsset(computeService *compute.Service, assetName string) (ForwardingRule, error) {
-	nameSplit := strings.Split(assetName, "/")
-	project := nameSplit[4]
-	region := nameSplit[6]
-	resourceId := nameSplit[len(nameSplit)-1]
-
-	assetGetCall := computeService.ForwardingRules.Get(project, region, resourceId)
-	asset, err := assetGetCall.Do()
+func (z *ForwardingRule) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
 	if err != nil {
-		return ForwardingRule{}, err
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.ForwardingRule
+	if name.Region != "" {
+		asset, err = computeService.ForwardingRules.Get(name.Project, name.Region, name.Resource).Do()
 	} else {
+		asset, err = computeService.GlobalForwardingRules.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*ForwardingRule)(asset), nil
+}
 
-		return ForwardingRule(*asset), nil
+// forwardingRuleRow adds the derived PscEndpointType column to the ForwardingRule table
+type forwardingRuleRow struct {
+	*ForwardingRule
+	PscEndpointType string `json:"pscEndpointType,omitempty"`
+}
+
+// PscEndpointType classifies Private Service Connect endpoints
+// SERVICE_ATTACHMENT: the forwarding rule targets a published service (serviceAttachments)
+// GOOGLE_APIS: the forwarding rule targets a Google APIs bundle (all-apis or vpc-sc)
+func (z ForwardingRule) PscEndpointType() string {
+	if strings.Contains(z.Target, "/serviceAttachments/") {
+		return "SERVICE_ATTACHMENT"
+	}
+	if z.Target == "all-apis" || z.Target == "vpc-sc" {
+		return "GOOGLE_APIS"
 	}
+	return ""
 }
 
 func (z ForwardingRule) GetSchema() (bigquery.Schema, error) {
-	schema, err := InferSchema(z)
+	schema, err := InferAssetSchema(z)
 	if err != nil {
 		return nil, err
 	}
 
 	field := bigquery.FieldSchema{}
-	field.Name = "UpdatedTimestamp"
-	field.Type = bigquery.TimestampFieldType
+	field.Name = "PscEndpointType"
+	field.Type = bigquery.StringFieldType
 	field.Required = false
 	field.Repeated = false
 	schema = append(schema, &field)
@@ -54,100 +79,14 @@ func (z ForwardingRule) GetSchema() (bigquery.Schema, error) {
 	return schema, nil
 }
 
-func (z *ForwardingRule) RefreshAssetInventory(projectID string, datasetID string, assetInventoryTableID string) {
-	assetTableID := z.AssetTableID()
-	assetType := z.AssetType()
-	schema, err := z.GetSchema()
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-
-	computeService, err := gcpComputeService()
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-	_ = computeService
-	tableExist, err := bqTableExist(projectID, datasetID, assetTableID)
-
-	// If the table does not exists then Create
-	if !(tableExist) {
-		if err := bqTableCreate(projectID, datasetID, assetTableID, schema); err != nil {
-			fmt.Println(err)
-			os.Exit(1)
-		}
-	}
-
-	assets, err := bqQueryAssetCompare(projectID, datasetID, assetInventoryTableID, assetTableID, assetType)
-	if err != nil {
-		fmt.Println(err)
-		os.Exit(1)
-	}
-	for i := 0; i < len(assets); i++ {
-		asset := assets[i]
-		if asset.Action != "CREATE" {
-			if err := bqAssetDelete(projectID, datasetID, assetTableID, asset.SelfLink); err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
-		}
-		if asset.Action != "DELETE" {
-			assetDetail, err := z.GetAsset(computeService, asset.Name)
-			if err != nil {
-				fmt.Println(err)
-				os.Exit(1)
-			}
-			if err := assetDetail.InsertAssetBQ(projectID, datasetID); err != nil {
-				fmt.Println(err)
-			}
-		}
-	}
-}
-
 func (z *ForwardingRule) InsertAssetBQ(projectID string, datasetID string) error {
 	if z.SelfLink == "" {
 		return fmt.Errorf("SelfLink is a required field")
 	}
-	asset := z
-	assetTableID := asset.AssetTableID()
-
-	ctx := context.Background()
-	client, err := bigquery.NewClient(ctx, projectID)
-	if err != nil {
-		return fmt.Errorf("bigquery.NewClient: %v", err)
-	}
-	defer client.Close()
-
-	assetJSON, _ := json.Marshal(asset)
-
-	bqReaderSource := bigquery.NewReaderSource(strings.NewReader(string(assetJSON)))
-
-	bqReaderSource.SourceFormat = bigquery.JSON
 	schema, err := z.GetSchema()
 	if err != nil {
-		fmt.Println(err)
-	}
-	bqReaderSource.Schema = schema
-	bqReaderSource.IgnoreUnknownValues = true
-
-	table := client.Dataset(datasetID).Table(assetTableID)
-	loader := table.LoaderFrom(bqReaderSource)
-
-	loader.CreateDisposition = bigquery.CreateNever
-
-	job, err := loader.Run(ctx)
-	if err != nil {
-		fmt.Println(err)
-	}
-
-	status, err := job.Wait(ctx)
-	if err != nil {
-		fmt.Println(err)
-	}
-	if status.Err() != nil {
-		fmt.Println(status.Err())
+		return err
 	}
 
-	return nil
+	return bqAssetInsert(projectID, datasetID, z.AssetTableID(), schema, forwardingRuleRow{z, z.PscEndpointType()})
 }
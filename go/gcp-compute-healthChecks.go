@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type HealthCheck compute.HealthCheck
+
+func init() {
+	RegisterAssetHandler(&HealthCheck{})
+}
+
+func (z HealthCheck) AssetType() string {
+	return "compute.googleapis.com/HealthCheck"
+}
+func (z HealthCheck) AssetTableID() string {
+	return "compute_googleapis_com_HealthCheck"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/healthChecks/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionHealthChecks/get
+func (z *HealthCheck) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.HealthCheck
+	if name.Region != "" {
+		asset, err = computeService.RegionHealthChecks.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.HealthChecks.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*HealthCheck)(asset), nil
+}
+
+func (z HealthCheck) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *HealthCheck) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type Image compute.Image
+
+func init() {
+	RegisterAssetHandler(&Image{})
+}
+
+func (z Image) AssetType() string {
+	return "compute.googleapis.com/Image"
+}
+func (z Image) AssetTableID() string {
+	return "compute_googleapis_com_Image"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/images/get
+func (z *Image) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.Images.Get(name.Project, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*Image)(asset), nil
+}
+
+func (z Image) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Image) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type InstanceGroupManager compute.InstanceGroupManager
+
+func init() {
+	RegisterAssetHandler(&InstanceGroupManager{})
+}
+
+func (z InstanceGroupManager) AssetType() string {
+	return "compute.googleapis.com/InstanceGroupManager"
+}
+func (z InstanceGroupManager) AssetTableID() string {
+	return "compute_googleapis_com_InstanceGroupManager"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroupManagers/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionInstanceGroupManagers/get
+func (z *InstanceGroupManager) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.InstanceGroupManager
+	if name.Zone != "" {
+		asset, err = computeService.InstanceGroupManagers.Get(name.Project, name.Zone, name.Resource).Do()
+	} else if name.Region != "" {
+		asset, err = computeService.RegionInstanceGroupManagers.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		return nil, fmt.Errorf("InstanceGroupManager:GetAsset %s is not a supported asset name", assetName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*InstanceGroupManager)(asset), nil
+}
+
+func (z InstanceGroupManager) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *InstanceGroupManager) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type InstanceGroup compute.InstanceGroup
+
+func init() {
+	RegisterAssetHandler(&InstanceGroup{})
+}
+
+func (z InstanceGroup) AssetType() string {
+	return "compute.googleapis.com/InstanceGroup"
+}
+func (z InstanceGroup) AssetTableID() string {
+	return "compute_googleapis_com_InstanceGroup"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceGroups/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionInstanceGroups/get
+func (z *InstanceGroup) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.InstanceGroup
+	if name.Zone != "" {
+		asset, err = computeService.InstanceGroups.Get(name.Project, name.Zone, name.Resource).Do()
+	} else if name.Region != "" {
+		asset, err = computeService.RegionInstanceGroups.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		return nil, fmt.Errorf("InstanceGroup:GetAsset %s is not a supported asset name", assetName)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*InstanceGroup)(asset), nil
+}
+
+func (z InstanceGroup) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *InstanceGroup) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
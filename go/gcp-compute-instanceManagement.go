@@ -0,0 +1,47 @@
+package main
+
+// derived_compute_InstanceManagement maps every instance to the managed instance group, instance template
+// and autoscaler that manage it. Instances created by a managed instance group carry the created-by and
+// instance-template metadata keys, both reference the project by number so they are matched within the
+// project of the instance on the zone or region and name. Hand-built instances have Managed = FALSE.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "derived_compute_InstanceManagement",
+		AssetTableIDs: []string{
+			(Instance{}).AssetTableID(),
+			(InstanceGroupManager{}).AssetTableID(),
+			(InstanceTemplate{}).AssetTableID(),
+			(Autoscaler{}).AssetTableID(),
+		},
+		Materialized: true,
+		Query: `
+			WITH instance AS (
+				SELECT
+					SelfLink,
+					Name,
+					Zone,
+					REGEXP_EXTRACT(SelfLink, r'projects/([^/]+)/') AS Project,
+					(SELECT item.Value FROM UNNEST(Metadata.Items) AS item WHERE item.Key = 'created-by') AS CreatedBy,
+					(SELECT item.Value FROM UNNEST(Metadata.Items) AS item WHERE item.Key = 'instance-template') AS InstanceTemplate
+				FROM ${dataset}.compute_googleapis_com_Instance
+			)
+			SELECT
+				instance.SelfLink AS Instance,
+				instance.Name AS InstanceName,
+				instance.Zone,
+				instanceGroupManager.SelfLink IS NOT NULL AS Managed,
+				instanceGroupManager.SelfLink AS InstanceGroupManager,
+				instanceGroupManager.InstanceGroup,
+				COALESCE(instanceTemplate.SelfLink, instanceGroupManager.InstanceTemplate, instance.InstanceTemplate) AS InstanceTemplate,
+				autoscaler.SelfLink AS Autoscaler
+			FROM instance
+			LEFT JOIN ${dataset}.compute_googleapis_com_InstanceGroupManager AS instanceGroupManager
+				ON REGEXP_EXTRACT(instanceGroupManager.SelfLink, r'projects/([^/]+)/') = instance.Project
+				AND REGEXP_EXTRACT(instanceGroupManager.SelfLink, r'/((?:zones|regions)/[^/]+/instanceGroupManagers/.*)') = REGEXP_EXTRACT(instance.CreatedBy, r'((?:zones|regions)/[^/]+/instanceGroupManagers/.*)')
+			LEFT JOIN ${dataset}.compute_googleapis_com_InstanceTemplate AS instanceTemplate
+				ON REGEXP_EXTRACT(instanceTemplate.SelfLink, r'projects/([^/]+)/') = instance.Project
+				AND REGEXP_EXTRACT(instanceTemplate.SelfLink, r'/((?:global|regions/[^/]+)/instanceTemplates/.*)') = REGEXP_EXTRACT(instance.InstanceTemplate, r'((?:global|regions/[^/]+)/instanceTemplates/.*)')
+			LEFT JOIN ${dataset}.compute_googleapis_com_Autoscaler AS autoscaler
+				ON autoscaler.Target = instanceGroupManager.SelfLink`,
+	})
+}
@@ -0,0 +1,29 @@
+package main
+
+// view_compute_InstanceServiceAccount has one row per instance and attached service account, joined with
+// the service account row matching the email. ServiceAccount is NULL when the service account is not part
+// of the inventory, for example when it belongs to another project.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_compute_InstanceServiceAccount",
+		AssetTableIDs: []string{
+			(Instance{}).AssetTableID(),
+			(ServiceAccount{}).AssetTableID(),
+		},
+		Query: `
+			SELECT
+				instance.SelfLink AS Instance,
+				instance.Name AS InstanceName,
+				instance.Zone,
+				instanceServiceAccount.Email,
+				instanceServiceAccount.Scopes,
+				serviceAccount.SelfLink AS ServiceAccount,
+				serviceAccount.DisplayName,
+				serviceAccount.Disabled,
+				ENDS_WITH(instanceServiceAccount.Email, '-compute@developer.gserviceaccount.com') AS DefaultComputeServiceAccount
+			FROM ${dataset}.compute_googleapis_com_Instance AS instance,
+				UNNEST(instance.ServiceAccounts) AS instanceServiceAccount
+			LEFT JOIN ${dataset}.iam_googleapis_com_ServiceAccount AS serviceAccount
+				ON serviceAccount.Email = instanceServiceAccount.Email`,
+	})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type InstanceTemplate compute.InstanceTemplate
+
+func init() {
+	RegisterAssetHandler(&InstanceTemplate{})
+}
+
+func (z InstanceTemplate) AssetType() string {
+	return "compute.googleapis.com/InstanceTemplate"
+}
+func (z InstanceTemplate) AssetTableID() string {
+	return "compute_googleapis_com_InstanceTemplate"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/instanceTemplates/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionInstanceTemplates/get
+func (z *InstanceTemplate) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.InstanceTemplate
+	if name.Region != "" {
+		asset, err = computeService.RegionInstanceTemplates.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.InstanceTemplates.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*InstanceTemplate)(asset), nil
+}
+
+func (z InstanceTemplate) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *InstanceTemplate) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
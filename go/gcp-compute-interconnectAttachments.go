@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type InterconnectAttachment compute.InterconnectAttachment
+
+func init() {
+	RegisterAssetHandler(&InterconnectAttachment{})
+}
+
+func (z InterconnectAttachment) AssetType() string {
+	return "compute.googleapis.com/InterconnectAttachment"
+}
+func (z InterconnectAttachment) AssetTableID() string {
+	return "compute_googleapis_com_InterconnectAttachment"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/interconnectAttachments/get
+func (z *InterconnectAttachment) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.InterconnectAttachments.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*InterconnectAttachment)(asset), nil
+}
+
+func (z InterconnectAttachment) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *InterconnectAttachment) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
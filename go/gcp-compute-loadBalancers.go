@@ -0,0 +1,89 @@
+package main
+
+// view_compute_LoadBalancer traces every forwarding rule through its target proxy and url map
+// to the backend services, one row per forwarding rule and backend service.
+// Internal passthrough load balancers reference the backend service directly from the forwarding rule.
+// Group is a reserved keyword in BigQuery and has to be quoted.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_compute_LoadBalancer",
+		AssetTableIDs: []string{
+			(ForwardingRule{}).AssetTableID(),
+			(TargetHttpProxy{}).AssetTableID(),
+			(TargetHttpsProxy{}).AssetTableID(),
+			(TargetTcpProxy{}).AssetTableID(),
+			(TargetSslProxy{}).AssetTableID(),
+			(TargetPool{}).AssetTableID(),
+			(UrlMap{}).AssetTableID(),
+			(BackendService{}).AssetTableID(),
+		},
+		Query: `
+			WITH targetProxy AS (
+				SELECT SelfLink, 'TargetHttpProxy' AS TargetType, UrlMap, CAST(NULL AS STRING) AS Service
+				FROM ${dataset}.compute_googleapis_com_TargetHttpProxy
+				UNION ALL
+				SELECT SelfLink, 'TargetHttpsProxy', UrlMap, CAST(NULL AS STRING)
+				FROM ${dataset}.compute_googleapis_com_TargetHttpsProxy
+				UNION ALL
+				SELECT SelfLink, 'TargetTcpProxy', CAST(NULL AS STRING), Service
+				FROM ${dataset}.compute_googleapis_com_TargetTcpProxy
+				UNION ALL
+				SELECT SelfLink, 'TargetSslProxy', CAST(NULL AS STRING), Service
+				FROM ${dataset}.compute_googleapis_com_TargetSslProxy
+				UNION ALL
+				SELECT SelfLink, 'TargetPool', CAST(NULL AS STRING), CAST(NULL AS STRING)
+				FROM ${dataset}.compute_googleapis_com_TargetPool
+			),
+			urlMapService AS (
+				SELECT SelfLink AS UrlMap, DefaultService AS BackendService
+				FROM ${dataset}.compute_googleapis_com_UrlMap
+				UNION DISTINCT
+				SELECT urlMap.SelfLink, weightedBackendService.BackendService
+				FROM ${dataset}.compute_googleapis_com_UrlMap AS urlMap,
+					UNNEST(urlMap.DefaultRouteAction.WeightedBackendServices) AS weightedBackendService
+				UNION DISTINCT
+				SELECT urlMap.SelfLink, pathMatcher.DefaultService
+				FROM ${dataset}.compute_googleapis_com_UrlMap AS urlMap,
+					UNNEST(urlMap.PathMatchers) AS pathMatcher
+				UNION DISTINCT
+				SELECT urlMap.SelfLink, pathRule.Service
+				FROM ${dataset}.compute_googleapis_com_UrlMap AS urlMap,
+					UNNEST(urlMap.PathMatchers) AS pathMatcher,
+					UNNEST(pathMatcher.PathRules) AS pathRule
+				UNION DISTINCT
+				SELECT urlMap.SelfLink, routeRule.Service
+				FROM ${dataset}.compute_googleapis_com_UrlMap AS urlMap,
+					UNNEST(urlMap.PathMatchers) AS pathMatcher,
+					UNNEST(pathMatcher.RouteRules) AS routeRule
+				UNION DISTINCT
+				SELECT urlMap.SelfLink, weightedBackendService.BackendService
+				FROM ${dataset}.compute_googleapis_com_UrlMap AS urlMap,
+					UNNEST(urlMap.PathMatchers) AS pathMatcher,
+					UNNEST(pathMatcher.RouteRules) AS routeRule,
+					UNNEST(routeRule.RouteAction.WeightedBackendServices) AS weightedBackendService
+			)
+			SELECT
+				forwardingRule.Name AS ForwardingRuleName,
+				forwardingRule.SelfLink AS ForwardingRule,
+				forwardingRule.IPAddress,
+				forwardingRule.IPProtocol,
+				forwardingRule.PortRange,
+				forwardingRule.Ports,
+				forwardingRule.LoadBalancingScheme,
+				forwardingRule.Network,
+				targetProxy.TargetType,
+				forwardingRule.Target,
+				targetProxy.UrlMap,
+				backendService.SelfLink AS BackendService,
+				backendService.Protocol AS BackendServiceProtocol,
+				backendService.HealthChecks,
+				ARRAY(SELECT backend.` + "`Group`" + ` FROM UNNEST(backendService.Backends) AS backend) AS BackendGroups
+			FROM ${dataset}.compute_googleapis_com_ForwardingRule AS forwardingRule
+			LEFT JOIN targetProxy
+				ON targetProxy.SelfLink = forwardingRule.Target
+			LEFT JOIN urlMapService
+				ON urlMapService.UrlMap = targetProxy.UrlMap
+			LEFT JOIN ${dataset}.compute_googleapis_com_BackendService AS backendService
+				ON backendService.SelfLink = COALESCE(urlMapService.BackendService, targetProxy.Service, forwardingRule.BackendService)`,
+	})
+}
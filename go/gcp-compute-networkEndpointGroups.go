@@ -0,0 +1,59 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// NetworkEndpointGroup covers zonal, regional (serverless and Private Service Connect) and global (internet) NEGs
+type NetworkEndpointGroup compute.NetworkEndpointGroup
+
+func init() {
+	RegisterAssetHandler(&NetworkEndpointGroup{})
+}
+
+func (z NetworkEndpointGroup) AssetType() string {
+	return "compute.googleapis.com/NetworkEndpointGroup"
+}
+func (z NetworkEndpointGroup) AssetTableID() string {
+	return "compute_googleapis_com_NetworkEndpointGroup"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/networkEndpointGroups/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionNetworkEndpointGroups/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/globalNetworkEndpointGroups/get
+func (z *NetworkEndpointGroup) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.NetworkEndpointGroup
+	if name.Zone != "" {
+		asset, err = computeService.NetworkEndpointGroups.Get(name.Project, name.Zone, name.Resource).Do()
+	} else if name.Region != "" {
+		asset, err = computeService.RegionNetworkEndpointGroups.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.GlobalNetworkEndpointGroups.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*NetworkEndpointGroup)(asset), nil
+}
+
+func (z NetworkEndpointGroup) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *NetworkEndpointGroup) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,36 @@
+package main
+
+// view_compute_PrivateServiceConnect has one row per Private Service Connect endpoint, joined with the
+// service attachment it connects to and the consumer network reported by the producer side.
+// Endpoints to Google APIs bundles have no service attachment.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_compute_PrivateServiceConnect",
+		AssetTableIDs: []string{
+			(ForwardingRule{}).AssetTableID(),
+			(ServiceAttachment{}).AssetTableID(),
+		},
+		Query: `
+			SELECT
+				forwardingRule.SelfLink AS ForwardingRule,
+				forwardingRule.Name AS ForwardingRuleName,
+				forwardingRule.PscEndpointType,
+				forwardingRule.PscConnectionStatus,
+				forwardingRule.IPAddress,
+				forwardingRule.Network,
+				forwardingRule.Subnetwork,
+				forwardingRule.Target,
+				serviceAttachment.SelfLink AS ServiceAttachment,
+				serviceAttachment.TargetService,
+				serviceAttachment.ConnectionPreference,
+				serviceAttachment.NatSubnets,
+				connectedEndpoint.Status AS ConnectedEndpointStatus,
+				connectedEndpoint.ConsumerNetwork
+			FROM ${dataset}.compute_googleapis_com_ForwardingRule AS forwardingRule
+			LEFT JOIN ${dataset}.compute_googleapis_com_ServiceAttachment AS serviceAttachment
+				ON serviceAttachment.SelfLink = forwardingRule.Target
+			LEFT JOIN UNNEST(serviceAttachment.ConnectedEndpoints) AS connectedEndpoint
+				ON connectedEndpoint.Endpoint = forwardingRule.SelfLink
+			WHERE forwardingRule.PscEndpointType IS NOT NULL`,
+	})
+}
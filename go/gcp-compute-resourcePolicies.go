@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ResourcePolicy stores snapshot schedules, instance schedules and group placement policies
+type ResourcePolicy compute.ResourcePolicy
+
+func init() {
+	RegisterAssetHandler(&ResourcePolicy{})
+}
+
+func (z ResourcePolicy) AssetType() string {
+	return "compute.googleapis.com/ResourcePolicy"
+}
+func (z ResourcePolicy) AssetTableID() string {
+	return "compute_googleapis_com_ResourcePolicy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/resourcePolicies/get
+func (z *ResourcePolicy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.ResourcePolicies.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*ResourcePolicy)(asset), nil
+}
+
+func (z ResourcePolicy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *ResourcePolicy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// Router stores the nested BGP peers, interfaces and Cloud NAT configs as records of the same table
+type Router compute.Router
+
+func init() {
+	RegisterAssetHandler(&Router{})
+
+	// view_compute_RouterBgpPeer has one row per BGP peer, joined with the interface it is configured on
+	RegisterAssetView(AssetView{
+		ViewID:        "view_compute_RouterBgpPeer",
+		AssetTableIDs: []string{(Router{}).AssetTableID()},
+		Query: `
+			SELECT
+				router.SelfLink AS Router,
+				router.Name AS RouterName,
+				router.Region,
+				router.Network,
+				router.Bgp.Asn,
+				bgpPeer.Name,
+				bgpPeer.Enable,
+				bgpPeer.ManagementType,
+				bgpPeer.PeerAsn,
+				bgpPeer.IpAddress,
+				bgpPeer.PeerIpAddress,
+				bgpPeer.AdvertiseMode,
+				bgpPeer.AdvertisedGroups,
+				bgpPeer.AdvertisedRoutePriority,
+				bgpPeer.InterfaceName,
+				routerInterface.LinkedVpnTunnel,
+				routerInterface.LinkedInterconnectAttachment
+			FROM ${dataset}.compute_googleapis_com_Router AS router,
+				UNNEST(router.BgpPeers) AS bgpPeer
+			LEFT JOIN UNNEST(router.Interfaces) AS routerInterface
+				ON routerInterface.Name = bgpPeer.InterfaceName`,
+	})
+
+	// view_compute_RouterNat has one row per Cloud NAT config and NATed subnetwork, a NULL Subnetwork
+	// means every subnetwork of the region is NATed according to SourceSubnetworkIpRangesToNat
+	RegisterAssetView(AssetView{
+		ViewID:        "view_compute_RouterNat",
+		AssetTableIDs: []string{(Router{}).AssetTableID()},
+		Query: `
+			SELECT
+				router.SelfLink AS Router,
+				router.Name AS RouterName,
+				router.Region,
+				router.Network,
+				nat.Name,
+				nat.Type,
+				nat.NatIpAllocateOption,
+				nat.NatIps,
+				nat.SourceSubnetworkIpRangesToNat,
+				nat.MinPortsPerVm,
+				nat.EnableEndpointIndependentMapping,
+				nat.LogConfig.Enable AS LogEnable,
+				subnetwork.Name AS Subnetwork,
+				subnetwork.SourceIpRangesToNat
+			FROM ${dataset}.compute_googleapis_com_Router AS router,
+				UNNEST(router.Nats) AS nat
+			LEFT JOIN UNNEST(nat.Subnetworks) AS subnetwork`,
+	})
+}
+
+func (z Router) AssetType() string {
+	return "compute.googleapis.com/Router"
+}
+func (z Router) AssetTableID() string {
+	return "compute_googleapis_com_Router"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/routers/get
+func (z *Router) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.Routers.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*Router)(asset), nil
+}
+
+func (z Router) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Router) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
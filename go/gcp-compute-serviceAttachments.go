@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// ServiceAttachment is the producer side of Private Service Connect, ConnectedEndpoints lists the consumer endpoints
+type ServiceAttachment compute.ServiceAttachment
+
+func init() {
+	RegisterAssetHandler(&ServiceAttachment{})
+}
+
+func (z ServiceAttachment) AssetType() string {
+	return "compute.googleapis.com/ServiceAttachment"
+}
+func (z ServiceAttachment) AssetTableID() string {
+	return "compute_googleapis_com_ServiceAttachment"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/serviceAttachments/get
+func (z *ServiceAttachment) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.ServiceAttachments.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*ServiceAttachment)(asset), nil
+}
+
+func (z ServiceAttachment) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *ServiceAttachment) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
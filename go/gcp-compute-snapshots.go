@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type Snapshot compute.Snapshot
+
+func init() {
+	RegisterAssetHandler(&Snapshot{})
+}
+
+func (z Snapshot) AssetType() string {
+	return "compute.googleapis.com/Snapshot"
+}
+func (z Snapshot) AssetTableID() string {
+	return "compute_googleapis_com_Snapshot"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/snapshots/get
+func (z *Snapshot) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.Snapshots.Get(name.Project, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*Snapshot)(asset), nil
+}
+
+func (z Snapshot) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Snapshot) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
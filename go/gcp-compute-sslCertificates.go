@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type SslCertificate compute.SslCertificate
+
+func init() {
+	RegisterAssetHandler(&SslCertificate{})
+}
+
+func (z SslCertificate) AssetType() string {
+	return "compute.googleapis.com/SslCertificate"
+}
+func (z SslCertificate) AssetTableID() string {
+	return "compute_googleapis_com_SslCertificate"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/sslCertificates/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionSslCertificates/get
+func (z *SslCertificate) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.SslCertificate
+	if name.Region != "" {
+		asset, err = computeService.RegionSslCertificates.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.SslCertificates.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*SslCertificate)(asset), nil
+}
+
+func (z SslCertificate) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *SslCertificate) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,41 @@
+package main
+
+// view_compute_StorageCleanup flags disks that are not attached to any instance and snapshots
+// older than env.GOOGLE_CLOUD_SNAPSHOT_MAX_AGE_DAYS (default 30 days)
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_compute_StorageCleanup",
+		AssetTableIDs: []string{
+			(Disk{}).AssetTableID(),
+			(Snapshot{}).AssetTableID(),
+		},
+		Query: `
+			SELECT
+				'Disk' AS ResourceType,
+				'UNATTACHED' AS Reason,
+				disk.SelfLink,
+				disk.Name,
+				IFNULL(disk.Zone, disk.Region) AS Location,
+				disk.SizeGb,
+				disk.Type,
+				SAFE_CAST(disk.CreationTimestamp AS TIMESTAMP) AS CreationTimestamp,
+				SAFE_CAST(disk.LastDetachTimestamp AS TIMESTAMP) AS LastUsedTimestamp,
+				DATE_DIFF(CURRENT_DATE(), DATE(SAFE_CAST(IFNULL(disk.LastDetachTimestamp, disk.CreationTimestamp) AS TIMESTAMP)), DAY) AS AgeDays
+			FROM ${dataset}.compute_googleapis_com_Disk AS disk
+			WHERE ARRAY_LENGTH(IFNULL(disk.Users, [])) = 0
+			UNION ALL
+			SELECT
+				'Snapshot' AS ResourceType,
+				'OLDER_THAN_${snapshot_max_age_days}_DAYS' AS Reason,
+				snapshot.SelfLink,
+				snapshot.Name,
+				ARRAY_TO_STRING(snapshot.StorageLocations, ',') AS Location,
+				snapshot.DiskSizeGb AS SizeGb,
+				snapshot.SnapshotType AS Type,
+				SAFE_CAST(snapshot.CreationTimestamp AS TIMESTAMP) AS CreationTimestamp,
+				CAST(NULL AS TIMESTAMP) AS LastUsedTimestamp,
+				DATE_DIFF(CURRENT_DATE(), DATE(SAFE_CAST(snapshot.CreationTimestamp AS TIMESTAMP)), DAY) AS AgeDays
+			FROM ${dataset}.compute_googleapis_com_Snapshot AS snapshot
+			WHERE SAFE_CAST(snapshot.CreationTimestamp AS TIMESTAMP) < TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL ${snapshot_max_age_days} DAY)`,
+	})
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetHttpProxy compute.TargetHttpProxy
+
+func init() {
+	RegisterAssetHandler(&TargetHttpProxy{})
+}
+
+func (z TargetHttpProxy) AssetType() string {
+	return "compute.googleapis.com/TargetHttpProxy"
+}
+func (z TargetHttpProxy) AssetTableID() string {
+	return "compute_googleapis_com_TargetHttpProxy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpProxies/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionTargetHttpProxies/get
+func (z *TargetHttpProxy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.TargetHttpProxy
+	if name.Region != "" {
+		asset, err = computeService.RegionTargetHttpProxies.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.TargetHttpProxies.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetHttpProxy)(asset), nil
+}
+
+func (z TargetHttpProxy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetHttpProxy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
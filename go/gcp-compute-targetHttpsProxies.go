@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetHttpsProxy compute.TargetHttpsProxy
+
+func init() {
+	RegisterAssetHandler(&TargetHttpsProxy{})
+}
+
+func (z TargetHttpsProxy) AssetType() string {
+	return "compute.googleapis.com/TargetHttpsProxy"
+}
+func (z TargetHttpsProxy) AssetTableID() string {
+	return "compute_googleapis_com_TargetHttpsProxy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetHttpsProxies/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionTargetHttpsProxies/get
+func (z *TargetHttpsProxy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.TargetHttpsProxy
+	if name.Region != "" {
+		asset, err = computeService.RegionTargetHttpsProxies.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.TargetHttpsProxies.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetHttpsProxy)(asset), nil
+}
+
+func (z TargetHttpsProxy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetHttpsProxy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetPool compute.TargetPool
+
+func init() {
+	RegisterAssetHandler(&TargetPool{})
+}
+
+func (z TargetPool) AssetType() string {
+	return "compute.googleapis.com/TargetPool"
+}
+func (z TargetPool) AssetTableID() string {
+	return "compute_googleapis_com_TargetPool"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetPools/get
+func (z *TargetPool) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.TargetPools.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetPool)(asset), nil
+}
+
+func (z TargetPool) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetPool) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetSslProxy compute.TargetSslProxy
+
+func init() {
+	RegisterAssetHandler(&TargetSslProxy{})
+}
+
+func (z TargetSslProxy) AssetType() string {
+	return "compute.googleapis.com/TargetSslProxy"
+}
+func (z TargetSslProxy) AssetTableID() string {
+	return "compute_googleapis_com_TargetSslProxy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetSslProxies/get
+func (z *TargetSslProxy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.TargetSslProxies.Get(name.Project, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetSslProxy)(asset), nil
+}
+
+func (z TargetSslProxy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetSslProxy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetTcpProxy compute.TargetTcpProxy
+
+func init() {
+	RegisterAssetHandler(&TargetTcpProxy{})
+}
+
+func (z TargetTcpProxy) AssetType() string {
+	return "compute.googleapis.com/TargetTcpProxy"
+}
+func (z TargetTcpProxy) AssetTableID() string {
+	return "compute_googleapis_com_TargetTcpProxy"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetTcpProxies/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionTargetTcpProxies/get
+func (z *TargetTcpProxy) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.TargetTcpProxy
+	if name.Region != "" {
+		asset, err = computeService.RegionTargetTcpProxies.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.TargetTcpProxies.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetTcpProxy)(asset), nil
+}
+
+func (z TargetTcpProxy) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetTcpProxy) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type TargetVpnGateway compute.TargetVpnGateway
+
+func init() {
+	RegisterAssetHandler(&TargetVpnGateway{})
+}
+
+func (z TargetVpnGateway) AssetType() string {
+	return "compute.googleapis.com/TargetVpnGateway"
+}
+func (z TargetVpnGateway) AssetTableID() string {
+	return "compute_googleapis_com_TargetVpnGateway"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/targetVpnGateways/get
+func (z *TargetVpnGateway) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.TargetVpnGateways.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*TargetVpnGateway)(asset), nil
+}
+
+func (z TargetVpnGateway) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *TargetVpnGateway) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type UrlMap compute.UrlMap
+
+func init() {
+	RegisterAssetHandler(&UrlMap{})
+}
+
+func (z UrlMap) AssetType() string {
+	return "compute.googleapis.com/UrlMap"
+}
+func (z UrlMap) AssetTableID() string {
+	return "compute_googleapis_com_UrlMap"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/urlMaps/get
+// https://cloud.google.com/compute/docs/reference/rest/v1/regionUrlMaps/get
+func (z *UrlMap) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	var asset *compute.UrlMap
+	if name.Region != "" {
+		asset, err = computeService.RegionUrlMaps.Get(name.Project, name.Region, name.Resource).Do()
+	} else {
+		asset, err = computeService.UrlMaps.Get(name.Project, name.Resource).Do()
+	}
+	if err != nil {
+		return nil, err
+	}
+	return (*UrlMap)(asset), nil
+}
+
+func (z UrlMap) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *UrlMap) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type VpnGateway compute.VpnGateway
+
+func init() {
+	RegisterAssetHandler(&VpnGateway{})
+}
+
+func (z VpnGateway) AssetType() string {
+	return "compute.googleapis.com/VpnGateway"
+}
+func (z VpnGateway) AssetTableID() string {
+	return "compute_googleapis_com_VpnGateway"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/vpnGateways/get
+func (z *VpnGateway) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.VpnGateways.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*VpnGateway)(asset), nil
+}
+
+func (z VpnGateway) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *VpnGateway) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/compute/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+type VpnTunnel compute.VpnTunnel
+
+func init() {
+	RegisterAssetHandler(&VpnTunnel{})
+}
+
+func (z VpnTunnel) AssetType() string {
+	return "compute.googleapis.com/VpnTunnel"
+}
+func (z VpnTunnel) AssetTableID() string {
+	return "compute_googleapis_com_VpnTunnel"
+}
+
+// https://cloud.google.com/compute/docs/reference/rest/v1/vpnTunnels/get
+func (z *VpnTunnel) GetAsset(assetName string) (AssetHandler, error) {
+	computeService, err := sharedComputeService()
+	if err != nil {
+		return nil, err
+	}
+
+	name := parseComputeAssetName(assetName)
+
+	asset, err := computeService.VpnTunnels.Get(name.Project, name.Region, name.Resource).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*VpnTunnel)(asset), nil
+}
+
+func (z VpnTunnel) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *VpnTunnel) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
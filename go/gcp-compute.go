@@ -1,6 +1,8 @@
 package main
 
 import (
+	"strings"
+
 	"golang.org/x/net/context"
 	"google.golang.org/api/compute/v1"
 
@@ -17,3 +19,36 @@ func gcpComputeService() (*compute.Service, error) {
 	}
 	return compute.New(client)
 }
+
+// sharedComputeService returns a compute service that is created once and reused by every handler
+var sharedComputeService = cachedService(gcpComputeService)
+
+// computeAssetName is the parsed form of a compute asset name, for example
+// //compute.googleapis.com/projects/PROJECT/global/backendServices/NAME
+// //compute.googleapis.com/projects/PROJECT/regions/REGION/backendServices/NAME
+// //compute.googleapis.com/projects/PROJECT/zones/ZONE/instances/NAME
+type computeAssetName struct {
+	Project  string
+	Region   string
+	Zone     string
+	Resource string
+}
+
+func parseComputeAssetName(assetName string) computeAssetName {
+	var name computeAssetName
+	nameSplit := strings.Split(assetName, "/")
+	for i := 0; i < len(nameSplit)-1; i++ {
+		switch nameSplit[i] {
+		case "projects":
+			if name.Project == "" {
+				name.Project = nameSplit[i+1]
+			}
+		case "regions":
+			name.Region = nameSplit[i+1]
+		case "zones":
+			name.Zone = nameSplit[i+1]
+		}
+	}
+	name.Resource = nameSplit[len(nameSplit)-1]
+	return name
+}
@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestParseComputeAssetName(t *testing.T) {
+	tests := []struct {
+		assetName string
+		want      computeAssetName
+	}{
+		{
+			"//compute.googleapis.com/projects/my-project/global/backendServices/web",
+			computeAssetName{Project: "my-project", Resource: "web"},
+		},
+		{
+			"//compute.googleapis.com/projects/my-project/regions/us-central1/backendServices/web",
+			computeAssetName{Project: "my-project", Region: "us-central1", Resource: "web"},
+		},
+		{
+			"//compute.googleapis.com/projects/my-project/zones/us-central1-a/instances/vm-1",
+			computeAssetName{Project: "my-project", Zone: "us-central1-a", Resource: "vm-1"},
+		},
+		{
+			// The project of a resource named projects is the first one
+			"//compute.googleapis.com/projects/my-project/global/networks/projects",
+			computeAssetName{Project: "my-project", Resource: "projects"},
+		},
+		{
+			"//compute.googleapis.com/projects/my-project",
+			computeAssetName{Project: "my-project", Resource: "my-project"},
+		},
+	}
+	for _, tt := range tests {
+		if got := parseComputeAssetName(tt.assetName); got != tt.want {
+			t.Errorf("parseComputeAssetName(%q) = %+v, want %+v", tt.assetName, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/container/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// Cluster stores GKE clusters with their node pools as records of the same table
+type Cluster container.Cluster
+
+func init() {
+	RegisterAssetHandler(&Cluster{})
+
+	// view_container_NodePool has one row per node pool
+	RegisterAssetView(AssetView{
+		ViewID:        "view_container_NodePool",
+		AssetTableIDs: []string{(Cluster{}).AssetTableID()},
+		Query: `
+			SELECT
+				cluster.SelfLink AS Cluster,
+				cluster.Name AS ClusterName,
+				cluster.Location,
+				nodePool.SelfLink AS NodePool,
+				nodePool.Name,
+				nodePool.Status,
+				nodePool.Version,
+				nodePool.Locations,
+				nodePool.InitialNodeCount,
+				nodePool.Autoscaling.Enabled AS AutoscalingEnabled,
+				nodePool.Autoscaling.MinNodeCount,
+				nodePool.Autoscaling.MaxNodeCount,
+				nodePool.Config.MachineType,
+				nodePool.Config.DiskType,
+				nodePool.Config.DiskSizeGb,
+				nodePool.Config.ImageType,
+				nodePool.Config.ServiceAccount,
+				nodePool.Config.Preemptible,
+				nodePool.Config.Spot,
+				nodePool.Management.AutoUpgrade,
+				nodePool.Management.AutoRepair,
+				nodePool.NetworkConfig.PodRange,
+				nodePool.NetworkConfig.PodIpv4CidrBlock,
+				nodePool.InstanceGroupUrls
+			FROM ${dataset}.container_googleapis_com_Cluster AS cluster,
+				UNNEST(cluster.NodePools) AS nodePool`,
+	})
+
+	// view_container_ClusterNetwork links every cluster to its Network and Subnetwork rows,
+	// NetworkConfig references them by relative name (projects/PROJECT/global/networks/NAME)
+	RegisterAssetView(AssetView{
+		ViewID: "view_container_ClusterNetwork",
+		AssetTableIDs: []string{
+			(Cluster{}).AssetTableID(),
+			(Network{}).AssetTableID(),
+			(Subnetwork{}).AssetTableID(),
+		},
+		Query: `
+			SELECT
+				cluster.SelfLink AS Cluster,
+				cluster.Name AS ClusterName,
+				cluster.Location,
+				network.SelfLink AS Network,
+				subnetwork.SelfLink AS Subnetwork,
+				subnetwork.IpCidrRange AS SubnetworkIpCidrRange,
+				cluster.ClusterIpv4Cidr,
+				cluster.ServicesIpv4Cidr,
+				cluster.IpAllocationPolicy.ClusterSecondaryRangeName,
+				cluster.IpAllocationPolicy.ServicesSecondaryRangeName,
+				cluster.PrivateClusterConfig.EnablePrivateNodes,
+				cluster.PrivateClusterConfig.EnablePrivateEndpoint,
+				cluster.PrivateClusterConfig.MasterIpv4CidrBlock,
+				cluster.Endpoint
+			FROM ${dataset}.container_googleapis_com_Cluster AS cluster
+			LEFT JOIN ${dataset}.compute_googleapis_com_Network AS network
+				ON REGEXP_EXTRACT(network.SelfLink, r'projects/.*') = cluster.NetworkConfig.Network
+			LEFT JOIN ${dataset}.compute_googleapis_com_Subnetwork AS subnetwork
+				ON REGEXP_EXTRACT(subnetwork.SelfLink, r'projects/.*') = cluster.NetworkConfig.Subnetwork`,
+	})
+}
+
+func (z Cluster) AssetType() string {
+	return "container.googleapis.com/Cluster"
+}
+func (z Cluster) AssetTableID() string {
+	return "container_googleapis_com_Cluster"
+}
+
+// https://cloud.google.com/kubernetes-engine/docs/reference/rest/v1/projects.locations.clusters/get
+func (z *Cluster) GetAsset(assetName string) (AssetHandler, error) {
+	containerService, err := sharedContainerService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //container.googleapis.com/projects/PROJECT/locations/LOCATION/clusters/NAME
+	// zonal clusters can still be named with zones/ instead of locations/
+	index := strings.Index(assetName, "projects/")
+	if index < 0 {
+		return nil, fmt.Errorf("Cluster:GetAsset %s is not a supported asset name", assetName)
+	}
+	name := strings.Replace(assetName[index:], "/zones/", "/locations/", 1)
+
+	asset, err := containerService.Projects.Locations.Clusters.Get(name).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*Cluster)(asset), nil
+}
+
+func (z Cluster) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *Cluster) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
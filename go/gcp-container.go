@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/container/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpContainerService() (*container.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, container.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return container.New(client)
+}
+
+// sharedContainerService returns a container service that is created once and reused by every handler
+var sharedContainerService = cachedService(gcpContainerService)
@@ -0,0 +1,406 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+)
+
+var DiscoveryDebugLevel = DebugLevel(ERROR)
+
+// Nested records deeper than this are dropped, BigQuery supports at most 15 levels
+const discoveryMaxDepth = 10
+
+// discoveryClient fetches discovery documents, a hung request must not stall the whole run
+var discoveryClient = &http.Client{Timeout: 30 * time.Second}
+
+var bqFieldName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]{0,299}$`)
+
+// DiscoveryAsset is the handler used for any asset type that does not have a hand-written handler.
+// The detailed table schema is built from the discovery document referenced by the asset inventory
+// and each row is the Resource.Data JSON already returned by ListAssets, so no additional API is called.
+// SelfLink of every row is set to the asset name so bqQueryAssetCompare can match it with the inventory.
+type DiscoveryAsset struct {
+	assetType            string
+	discoveryDocumentURL string
+	discoveryName        string
+	schema               bigquery.Schema
+	inventory            map[string]string
+	row                  map[string]interface{}
+}
+
+type discoveryDocument struct {
+	Schemas map[string]*discoverySchema `json:"schemas"`
+}
+
+type discoverySchema struct {
+	Type                 string                      `json:"type"`
+	Format               string                      `json:"format"`
+	Ref                  string                      `json:"$ref"`
+	Properties           map[string]*discoverySchema `json:"properties"`
+	Items                *discoverySchema            `json:"items"`
+	AdditionalProperties *discoverySchema            `json:"additionalProperties"`
+}
+
+var discoveryDocuments = struct {
+	sync.Mutex
+	documents map[string]*discoveryDocument
+}{documents: map[string]*discoveryDocument{}}
+
+// NewDiscoveryAsset reads every asset of assetType from the asset inventory table
+func NewDiscoveryAsset(projectID string, datasetID string, assetInventoryTableID string, assetType string) (*DiscoveryAsset, error) {
+	var queryString = fmt.Sprintf(`
+		SELECT name, resource.discovery_document_url, resource.discovery_name, resource.data
+		FROM %s.%s.%s
+		WHERE asset_type = '%s'`,
+		projectID, datasetID, assetInventoryTableID, assetType)
+	results, err := bqExecutQuery(projectID, queryString)
+	if err != nil {
+		return nil, fmt.Errorf("NewDiscoveryAsset:%s: %v", assetType, err)
+	}
+
+	z := &DiscoveryAsset{
+		assetType: assetType,
+		inventory: map[string]string{},
+	}
+	for _, result := range results {
+		row := result.([]bigquery.Value)
+		name, _ := row[0].(string)
+		discoveryDocumentURL, _ := row[1].(string)
+		discoveryName, _ := row[2].(string)
+		data, _ := row[3].(string)
+		if z.discoveryDocumentURL == "" {
+			z.discoveryDocumentURL = discoveryDocumentURL
+			z.discoveryName = discoveryName
+		}
+		z.inventory[name] = data
+	}
+	return z, nil
+}
+
+func (z DiscoveryAsset) AssetType() string {
+	return z.assetType
+}
+func (z DiscoveryAsset) AssetTableID() string {
+	return AssetTableIDFromType(z.assetType)
+}
+
+func (z *DiscoveryAsset) GetAsset(assetName string) (AssetHandler, error) {
+	data, exist := z.inventory[assetName]
+	if !(exist) {
+		return nil, fmt.Errorf("DiscoveryAsset:GetAsset %s was not found in the asset inventory", assetName)
+	}
+
+	var row map[string]interface{}
+	if err := json.Unmarshal([]byte(data), &row); err != nil {
+		return nil, fmt.Errorf("DiscoveryAsset:GetAsset %s: %v", assetName, err)
+	}
+	if row == nil {
+		row = map[string]interface{}{}
+	}
+	for key := range row {
+		if strings.EqualFold(key, "SelfLink") {
+			delete(row, key)
+		}
+	}
+	row["selfLink"] = assetName
+
+	asset := *z
+	asset.row = row
+	return &asset, nil
+}
+
+// GetSchema prefers the discovery document and falls back to the Resource.Data JSON of the inventory
+func (z *DiscoveryAsset) GetSchema() (bigquery.Schema, error) {
+	if z.schema != nil {
+		return z.schema, nil
+	}
+
+	schema, err := z.discoverySchema()
+	if err != nil {
+		if DiscoveryDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: DiscoveryAsset:GetSchema %s falling back to Resource.Data: %v \n", z.assetType, err)
+		}
+		schema = z.dataSchema()
+	}
+
+	hasSelfLink := false
+	for _, field := range schema {
+		if strings.EqualFold(field.Name, "SelfLink") {
+			field.Type = bigquery.StringFieldType
+			field.Repeated = false
+			field.Schema = nil
+			hasSelfLink = true
+		}
+	}
+	if !(hasSelfLink) {
+		schema = append(schema, &bigquery.FieldSchema{Name: "selfLink", Type: bigquery.StringFieldType})
+	}
+	schema = append(schema, &bigquery.FieldSchema{Name: "UpdatedTimestamp", Type: bigquery.TimestampFieldType})
+
+	z.schema = schema
+	return z.schema, nil
+}
+
+func (z *DiscoveryAsset) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.row == nil {
+		return fmt.Errorf("DiscoveryAsset:InsertAssetBQ GetAsset must be called before InsertAssetBQ")
+	}
+	schema, err := z.GetSchema()
+	if err != nil {
+		return err
+	}
+
+	return bqAssetInsert(projectID, datasetID, z.AssetTableID(), schema, z.row)
+}
+
+func (z *DiscoveryAsset) discoverySchema() (bigquery.Schema, error) {
+	if z.discoveryDocumentURL == "" || z.discoveryName == "" {
+		return nil, fmt.Errorf("no discovery document for %s", z.assetType)
+	}
+	document, err := getDiscoveryDocument(z.discoveryDocumentURL)
+	if err != nil {
+		return nil, err
+	}
+	resourceSchema, exist := document.Schemas[z.discoveryName]
+	if !(exist) {
+		return nil, fmt.Errorf("schema %s was not found in %s", z.discoveryName, z.discoveryDocumentURL)
+	}
+
+	schema := document.fields(resourceSchema, 0)
+	if len(schema) == 0 {
+		return nil, fmt.Errorf("schema %s in %s has no supported fields", z.discoveryName, z.discoveryDocumentURL)
+	}
+	return schema, nil
+}
+
+func getDiscoveryDocument(discoveryDocumentURL string) (*discoveryDocument, error) {
+	discoveryDocuments.Lock()
+	defer discoveryDocuments.Unlock()
+
+	if document, exist := discoveryDocuments.documents[discoveryDocumentURL]; exist {
+		return document, nil
+	}
+
+	response, err := discoveryClient.Get(discoveryDocumentURL)
+	if err != nil {
+		return nil, fmt.Errorf("http.Get: %v", err)
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("http.Get: %s returned %s", discoveryDocumentURL, response.Status)
+	}
+	body, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return nil, fmt.Errorf("ioutil.ReadAll: %v", err)
+	}
+
+	document := &discoveryDocument{}
+	if err := json.Unmarshal(body, document); err != nil {
+		return nil, fmt.Errorf("json.Unmarshal: %s: %v", discoveryDocumentURL, err)
+	}
+	if DiscoveryDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: getDiscoveryDocument %s contains %d schemas \n", discoveryDocumentURL, len(document.Schemas))
+	}
+	discoveryDocuments.documents[discoveryDocumentURL] = document
+	return document, nil
+}
+
+func (d *discoveryDocument) resolve(schema *discoverySchema) *discoverySchema {
+	if schema != nil && schema.Ref != "" {
+		return d.Schemas[schema.Ref]
+	}
+	return schema
+}
+
+func (d *discoveryDocument) fields(schema *discoverySchema, depth int) bigquery.Schema {
+	var fields bigquery.Schema
+	schema = d.resolve(schema)
+	if schema == nil {
+		return fields
+	}
+
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !(bqFieldName.MatchString(name)) {
+			if DiscoveryDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+				fmt.Printf("WARNING: discoveryDocument:fields %s is not a valid BigQuery field name \n", name)
+			}
+			continue
+		}
+		if field := d.field(name, schema.Properties[name], depth); field != nil {
+			fields = append(fields, field)
+		}
+	}
+	return fields
+}
+
+func (d *discoveryDocument) field(name string, schema *discoverySchema, depth int) *bigquery.FieldSchema {
+	schema = d.resolve(schema)
+	if schema == nil {
+		return nil
+	}
+
+	field := &bigquery.FieldSchema{Name: name}
+	switch schema.Type {
+	case "string":
+		field.Type = bigquery.StringFieldType
+		// uint64 values (e.g. compute ids) do not fit in an INTEGER
+		if schema.Format == "int64" || schema.Format == "int32" {
+			field.Type = bigquery.IntegerFieldType
+		}
+	case "integer":
+		field.Type = bigquery.IntegerFieldType
+	case "number":
+		field.Type = bigquery.FloatFieldType
+	case "boolean":
+		field.Type = bigquery.BooleanFieldType
+	case "array":
+		items := d.resolve(schema.Items)
+		if items == nil || items.Type == "array" {
+			return nil
+		}
+		itemField := d.field(name, items, depth)
+		if itemField == nil {
+			return nil
+		}
+		itemField.Repeated = true
+		return itemField
+	case "object":
+		// Maps are skipped in the same way as InferSchema does
+		if len(schema.Properties) == 0 || depth >= discoveryMaxDepth {
+			return nil
+		}
+		field.Type = bigquery.RecordFieldType
+		field.Schema = d.fields(schema, depth+1)
+		if len(field.Schema) == 0 {
+			return nil
+		}
+	default:
+		if DiscoveryDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: discoveryDocument:field %s is of type <%s> and is not currently supported \n", name, schema.Type)
+		}
+		return nil
+	}
+	return field
+}
+
+// dataSchema infers a schema from the Resource.Data JSON of every asset in the inventory
+func (z *DiscoveryAsset) dataSchema() bigquery.Schema {
+	var schema bigquery.Schema
+	for _, data := range z.inventory {
+		var row map[string]interface{}
+		if err := json.Unmarshal([]byte(data), &row); err != nil {
+			continue
+		}
+		schema = mergeJSONFields(schema, row, 0)
+	}
+	return schema
+}
+
+func mergeJSONFields(schema bigquery.Schema, row map[string]interface{}, depth int) bigquery.Schema {
+	var names []string
+	for name := range row {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if !(bqFieldName.MatchString(name)) {
+			continue
+		}
+		field := jsonField(name, row[name], depth)
+		if field == nil {
+			continue
+		}
+
+		var existing *bigquery.FieldSchema
+		for _, f := range schema {
+			if strings.EqualFold(f.Name, name) {
+				existing = f
+			}
+		}
+		if existing == nil {
+			schema = append(schema, field)
+		} else if existing.Type == bigquery.RecordFieldType && field.Type == bigquery.RecordFieldType {
+			for _, nested := range field.Schema {
+				existing.Schema = mergeFieldSchema(existing.Schema, nested)
+			}
+		}
+	}
+	return schema
+}
+
+func mergeFieldSchema(schema bigquery.Schema, field *bigquery.FieldSchema) bigquery.Schema {
+	for _, f := range schema {
+		if strings.EqualFold(f.Name, field.Name) {
+			if f.Type == bigquery.RecordFieldType && field.Type == bigquery.RecordFieldType {
+				for _, nested := range field.Schema {
+					f.Schema = mergeFieldSchema(f.Schema, nested)
+				}
+			}
+			return schema
+		}
+	}
+	return append(schema, field)
+}
+
+func jsonField(name string, value interface{}, depth int) *bigquery.FieldSchema {
+	field := &bigquery.FieldSchema{Name: name}
+	switch v := value.(type) {
+	case string:
+		field.Type = bigquery.StringFieldType
+	case float64:
+		field.Type = bigquery.FloatFieldType
+	case bool:
+		field.Type = bigquery.BooleanFieldType
+	case []interface{}:
+		var itemField *bigquery.FieldSchema
+		for _, item := range v {
+			if _, nested := item.([]interface{}); nested {
+				return nil
+			}
+			f := jsonField(name, item, depth)
+			if f == nil {
+				continue
+			}
+			if itemField == nil {
+				itemField = f
+			} else if itemField.Type == bigquery.RecordFieldType && f.Type == bigquery.RecordFieldType {
+				for _, nested := range f.Schema {
+					itemField.Schema = mergeFieldSchema(itemField.Schema, nested)
+				}
+			}
+		}
+		if itemField == nil {
+			return nil
+		}
+		itemField.Repeated = true
+		return itemField
+	case map[string]interface{}:
+		if depth >= discoveryMaxDepth {
+			return nil
+		}
+		field.Type = bigquery.RecordFieldType
+		field.Schema = mergeJSONFields(nil, v, depth+1)
+		if len(field.Schema) == 0 {
+			return nil
+		}
+	default:
+		return nil
+	}
+	return field
+}
@@ -0,0 +1,77 @@
+package main
+
+// view_dns_AddressRecord has one row per A and AAAA record IP address with the Address, ForwardingRule and
+// Instance rows using that IP address. NotInInventory is set when no reserved address, forwarding rule or
+// instance of the inventory uses the IP address, this includes records pointing at IP addresses owned by a
+// third party or another organization so it lists candidates for a dangling record rather than released ones.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_dns_AddressRecord",
+		AssetTableIDs: []string{
+			(ResourceRecordSet{}).AssetTableID(),
+			(Address{}).AssetTableID(),
+			(ForwardingRule{}).AssetTableID(),
+			(Instance{}).AssetTableID(),
+		},
+		Query: `
+			WITH addressRecord AS (
+				SELECT DISTINCT
+					recordSet.SelfLink AS ResourceRecordSet,
+					recordSet.ManagedZone,
+					recordSet.Name AS DnsName,
+					recordSet.Type,
+					recordSet.Ttl,
+					rrdata.IpAddress
+				FROM ${dataset}.dns_googleapis_com_ResourceRecordSet AS recordSet,
+					UNNEST(recordSet.ParsedRrdatas) AS rrdata
+				WHERE recordSet.Type IN ('A', 'AAAA') AND rrdata.IpAddress IS NOT NULL
+			),
+			addressByIp AS (
+				SELECT
+					address.Address AS IpAddress,
+					ARRAY_AGG(STRUCT(address.SelfLink, address.Status, address.AddressType) ORDER BY address.SelfLink) AS Addresses
+				FROM ${dataset}.compute_googleapis_com_Address AS address
+				WHERE address.Address IS NOT NULL
+				GROUP BY address.Address
+			),
+			forwardingRuleByIp AS (
+				SELECT
+					forwardingRule.IPAddress AS IpAddress,
+					ARRAY_AGG(DISTINCT forwardingRule.SelfLink ORDER BY forwardingRule.SelfLink) AS ForwardingRules
+				FROM ${dataset}.compute_googleapis_com_ForwardingRule AS forwardingRule
+				WHERE forwardingRule.IPAddress IS NOT NULL
+				GROUP BY forwardingRule.IPAddress
+			),
+			instanceAddress AS (
+				SELECT instance.SelfLink, networkInterface.NetworkIP AS IpAddress
+				FROM ${dataset}.compute_googleapis_com_Instance AS instance,
+					UNNEST(instance.NetworkInterfaces) AS networkInterface
+				UNION ALL
+				SELECT instance.SelfLink, accessConfig.NatIP AS IpAddress
+				FROM ${dataset}.compute_googleapis_com_Instance AS instance,
+					UNNEST(instance.NetworkInterfaces) AS networkInterface,
+					UNNEST(networkInterface.AccessConfigs) AS accessConfig
+			),
+			instanceByIp AS (
+				SELECT
+					instanceAddress.IpAddress,
+					ARRAY_AGG(DISTINCT instanceAddress.SelfLink ORDER BY instanceAddress.SelfLink) AS Instances
+				FROM instanceAddress
+				WHERE instanceAddress.IpAddress IS NOT NULL
+				GROUP BY instanceAddress.IpAddress
+			)
+			SELECT
+				addressRecord.*,
+				IFNULL(addressByIp.Addresses, []) AS Addresses,
+				IFNULL(forwardingRuleByIp.ForwardingRules, []) AS ForwardingRules,
+				IFNULL(instanceByIp.Instances, []) AS Instances,
+				addressByIp.IpAddress IS NULL AND forwardingRuleByIp.IpAddress IS NULL AND instanceByIp.IpAddress IS NULL AS NotInInventory
+			FROM addressRecord
+			LEFT JOIN addressByIp
+				ON addressByIp.IpAddress = addressRecord.IpAddress
+			LEFT JOIN forwardingRuleByIp
+				ON forwardingRuleByIp.IpAddress = addressRecord.IpAddress
+			LEFT JOIN instanceByIp
+				ON instanceByIp.IpAddress = addressRecord.IpAddress`,
+	})
+}
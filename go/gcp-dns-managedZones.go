@@ -0,0 +1,61 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/dns/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// DnsManagedZone is the managed zone returned by the Cloud DNS API
+type DnsManagedZone dns.ManagedZone
+
+// ManagedZone stores Cloud DNS managed zones
+type ManagedZone struct {
+	*DnsManagedZone
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&ManagedZone{})
+}
+
+func (z ManagedZone) AssetType() string {
+	return "dns.googleapis.com/ManagedZone"
+}
+func (z ManagedZone) AssetTableID() string {
+	return "dns_googleapis_com_ManagedZone"
+}
+
+// https://cloud.google.com/dns/docs/reference/v1/managedZones/get
+func (z *ManagedZone) GetAsset(assetName string) (AssetHandler, error) {
+	dnsService, err := sharedDnsService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //dns.googleapis.com/projects/PROJECT/managedZones/ZONE
+	nameSplit := strings.Split(AssetRelativeName(assetName), "/")
+	if len(nameSplit) != 4 {
+		return nil, fmt.Errorf("ManagedZone:GetAsset %s is not a supported asset name", assetName)
+	}
+
+	asset, err := dnsService.ManagedZones.Get(nameSplit[1], nameSplit[3]).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &ManagedZone{DnsManagedZone: (*DnsManagedZone)(asset), SelfLink: assetName}, nil
+}
+
+func (z ManagedZone) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(DnsManagedZone{})
+}
+
+func (z *ManagedZone) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.DnsManagedZone == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/dns/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// DnsResourceRecordSet is the record set returned by the Cloud DNS API
+type DnsResourceRecordSet dns.ResourceRecordSet
+
+// ResourceRecordSet stores one row per Cloud DNS record set with its rrdatas parsed according to the record type.
+// SelfLink is set to the asset name and ManagedZone to the asset name of the zone.
+type ResourceRecordSet struct {
+	*DnsResourceRecordSet
+	SelfLink      string                `json:"selfLink"`
+	ManagedZone   string                `json:"managedZone"`
+	ParsedRrdatas []*ResourceRecordData `json:"parsedRrdatas"`
+}
+
+// ResourceRecordData is a single parsed rrdata, only the fields used by the record type are set
+type ResourceRecordData struct {
+	Rrdata    string `json:"rrdata"`              // All types, as returned by the API
+	IpAddress string `json:"ipAddress,omitempty"` // A, AAAA
+	Target    string `json:"target,omitempty"`    // CNAME, NS, PTR, MX, SRV
+	Priority  int64  `json:"priority,omitempty"`  // MX, SRV
+	Weight    int64  `json:"weight,omitempty"`    // SRV
+	Port      int64  `json:"port,omitempty"`      // SRV
+	Text      string `json:"text,omitempty"`      // TXT, SPF without the quotes
+}
+
+func init() {
+	RegisterAssetHandler(&ResourceRecordSet{})
+}
+
+func (z ResourceRecordSet) AssetType() string {
+	return "dns.googleapis.com/ResourceRecordSet"
+}
+func (z ResourceRecordSet) AssetTableID() string {
+	return "dns_googleapis_com_ResourceRecordSet"
+}
+
+// https://cloud.google.com/dns/docs/reference/v1/resourceRecordSets/get
+func (z *ResourceRecordSet) GetAsset(assetName string) (AssetHandler, error) {
+	dnsService, err := sharedDnsService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //dns.googleapis.com/projects/PROJECT/managedZones/ZONE/rrsets/NAME/TYPE
+	nameSplit := strings.Split(AssetRelativeName(assetName), "/")
+	if len(nameSplit) != 7 {
+		return nil, fmt.Errorf("ResourceRecordSet:GetAsset %s is not a supported asset name", assetName)
+	}
+
+	asset, err := dnsService.ResourceRecordSets.Get(nameSplit[1], nameSplit[3], nameSplit[5], nameSplit[6]).Do()
+	if err != nil {
+		return nil, err
+	}
+
+	recordSet := &ResourceRecordSet{
+		DnsResourceRecordSet: (*DnsResourceRecordSet)(asset),
+		SelfLink:             assetName,
+		ManagedZone:          fmt.Sprintf("//dns.googleapis.com/projects/%s/managedZones/%s", nameSplit[1], nameSplit[3]),
+	}
+	for _, rrdata := range asset.Rrdatas {
+		recordSet.ParsedRrdatas = append(recordSet.ParsedRrdatas, parseRrdata(asset.Type, rrdata))
+	}
+	return recordSet, nil
+}
+
+func parseRrdata(recordType string, rrdata string) *ResourceRecordData {
+	data := &ResourceRecordData{Rrdata: rrdata}
+	fields := strings.Fields(rrdata)
+	switch recordType {
+	case "A", "AAAA":
+		data.IpAddress = rrdata
+	case "CNAME", "NS", "PTR":
+		data.Target = rrdata
+	case "MX":
+		if len(fields) == 2 {
+			data.Priority, _ = strconv.ParseInt(fields[0], 10, 64)
+			data.Target = fields[1]
+		}
+	case "SRV":
+		if len(fields) == 4 {
+			data.Priority, _ = strconv.ParseInt(fields[0], 10, 64)
+			data.Weight, _ = strconv.ParseInt(fields[1], 10, 64)
+			data.Port, _ = strconv.ParseInt(fields[2], 10, 64)
+			data.Target = fields[3]
+		}
+	case "TXT", "SPF":
+		data.Text = unquoteRrdata(rrdata)
+	}
+	return data
+}
+
+// unquoteRrdata joins the quoted strings of a TXT rrdata, long values are split in several quoted strings
+func unquoteRrdata(rrdata string) string {
+	if !(strings.Contains(rrdata, `"`)) {
+		return rrdata
+	}
+
+	var text strings.Builder
+	quoted := false
+	for i := 0; i < len(rrdata); i++ {
+		switch {
+		case rrdata[i] == '\\' && quoted && i+1 < len(rrdata):
+			i++
+			text.WriteByte(rrdata[i])
+		case rrdata[i] == '"':
+			quoted = !(quoted)
+		case quoted:
+			text.WriteByte(rrdata[i])
+		}
+	}
+	return text.String()
+}
+
+func (z ResourceRecordSet) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(DnsResourceRecordSet{})
+	if err != nil {
+		return nil, err
+	}
+
+	parsedSchema, err := InferSchema(struct {
+		ManagedZone   string
+		ParsedRrdatas []*ResourceRecordData
+	}{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, parsedSchema...), nil
+}
+
+func (z *ResourceRecordSet) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.DnsResourceRecordSet == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,52 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseRrdata(t *testing.T) {
+	tests := []struct {
+		recordType string
+		rrdata     string
+		want       ResourceRecordData
+	}{
+		{"A", "10.0.0.1", ResourceRecordData{Rrdata: "10.0.0.1", IpAddress: "10.0.0.1"}},
+		{"AAAA", "2001:db8::1", ResourceRecordData{Rrdata: "2001:db8::1", IpAddress: "2001:db8::1"}},
+		{"CNAME", "www.example.com.", ResourceRecordData{Rrdata: "www.example.com.", Target: "www.example.com."}},
+		{"NS", "ns-cloud-a1.googledomains.com.", ResourceRecordData{Rrdata: "ns-cloud-a1.googledomains.com.", Target: "ns-cloud-a1.googledomains.com."}},
+		{"MX", "10 mail.example.com.", ResourceRecordData{Rrdata: "10 mail.example.com.", Priority: 10, Target: "mail.example.com."}},
+		{"MX", "mail.example.com.", ResourceRecordData{Rrdata: "mail.example.com."}},
+		{"SRV", "10 5 5060 sip.example.com.", ResourceRecordData{Rrdata: "10 5 5060 sip.example.com.", Priority: 10, Weight: 5, Port: 5060, Target: "sip.example.com."}},
+		{"SRV", "10 5 sip.example.com.", ResourceRecordData{Rrdata: "10 5 sip.example.com."}},
+		{"TXT", `"v=spf1 include:_spf.google.com ~all"`, ResourceRecordData{Rrdata: `"v=spf1 include:_spf.google.com ~all"`, Text: "v=spf1 include:_spf.google.com ~all"}},
+		{"SPF", "v=spf1 -all", ResourceRecordData{Rrdata: "v=spf1 -all", Text: "v=spf1 -all"}},
+		{"CAA", `0 issue "pki.goog"`, ResourceRecordData{Rrdata: `0 issue "pki.goog"`}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.recordType+" "+tt.rrdata, func(t *testing.T) {
+			if got := parseRrdata(tt.recordType, tt.rrdata); !(reflect.DeepEqual(*got, tt.want)) {
+				t.Errorf("parseRrdata(%q, %q) = %+v, want %+v", tt.recordType, tt.rrdata, *got, tt.want)
+			}
+		})
+	}
+}
+
+func TestUnquoteRrdata(t *testing.T) {
+	tests := []struct {
+		rrdata string
+		want   string
+	}{
+		{`unquoted text`, `unquoted text`},
+		{`"quoted text"`, `quoted text`},
+		{`"first part" "second part"`, `first partsecond part`},
+		{`"escaped \"quote\""`, `escaped "quote"`},
+		{`"escaped \\ backslash"`, `escaped \ backslash`},
+		{`""`, ``},
+	}
+	for _, tt := range tests {
+		if got := unquoteRrdata(tt.rrdata); got != tt.want {
+			t.Errorf("unquoteRrdata(%q) = %q, want %q", tt.rrdata, got, tt.want)
+		}
+	}
+}
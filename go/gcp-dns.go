@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/dns/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpDnsService() (*dns.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, dns.NdevClouddnsReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+	return dns.New(client)
+}
+
+// sharedDnsService returns a dns service that is created once and reused by every handler
+var sharedDnsService = cachedService(gcpDnsService)
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/file/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// FileInstance is the Filestore instance returned by the API
+type FileInstance file.Instance
+
+// FilestoreInstance stores Filestore instances
+type FilestoreInstance struct {
+	*FileInstance
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&FilestoreInstance{})
+}
+
+func (z FilestoreInstance) AssetType() string {
+	return "file.googleapis.com/Instance"
+}
+func (z FilestoreInstance) AssetTableID() string {
+	return "file_googleapis_com_Instance"
+}
+
+// https://cloud.google.com/filestore/docs/reference/rest/v1/projects.locations.instances/get
+func (z *FilestoreInstance) GetAsset(assetName string) (AssetHandler, error) {
+	fileService, err := sharedFileService()
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := fileService.Projects.Locations.Instances.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &FilestoreInstance{FileInstance: (*FileInstance)(asset), SelfLink: assetName}, nil
+}
+
+func (z FilestoreInstance) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(FileInstance{})
+}
+
+func (z *FilestoreInstance) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.FileInstance == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/file/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpFileService() (*file.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, file.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return file.New(client)
+}
+
+// sharedFileService returns a file service that is created once and reused by every handler
+var sharedFileService = cachedService(gcpFileService)
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"google.golang.org/api/googleapi"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"cloud.google.com/go/bigquery"
+)
+
+var HandlerDebugLevel = DebugLevel(ERROR)
+
+// AssetHandler is implemented by every resource type that keeps a detailed table
+// next to the asset inventory table.
+type AssetHandler interface {
+	AssetType() string
+	AssetTableID() string
+	GetSchema() (bigquery.Schema, error)
+	GetAsset(assetName string) (AssetHandler, error)
+	InsertAssetBQ(projectID string, datasetID string) error
+}
+
+var assetHandlers = map[string]AssetHandler{}
+
+// RegisterAssetHandler makes a handler available to main(), it is expected to be called from init()
+func RegisterAssetHandler(handler AssetHandler) {
+	assetTableID := handler.AssetTableID()
+	if _, exist := assetHandlers[assetTableID]; exist {
+		panic(fmt.Sprintf("RegisterAssetHandler: handler already registered for %s", assetTableID))
+	}
+	assetHandlers[assetTableID] = handler
+}
+
+func LookupAssetHandler(assetTableID string) (AssetHandler, bool) {
+	handler, exist := assetHandlers[assetTableID]
+	return handler, exist
+}
+
+// AssetTableID for an asset type, compute.googleapis.com/Network is stored in compute_googleapis_com_Network
+func AssetTableIDFromType(assetType string) string {
+	assetTableID := strings.Replace(assetType, ".", "_", -1)
+	assetTableID = strings.Replace(assetTableID, "/", "_", -1)
+	return assetTableID
+}
+
+// AssetRelativeName strips the service from an asset name, the result is the resource name used by most
+// non compute APIs: //redis.googleapis.com/projects/PROJECT/locations/LOCATION/instances/NAME
+// returns projects/PROJECT/locations/LOCATION/instances/NAME, a name that is already relative is returned unchanged
+func AssetRelativeName(assetName string) string {
+	if !(strings.HasPrefix(assetName, "//")) {
+		return assetName
+	}
+	nameSplit := strings.SplitN(strings.TrimPrefix(assetName, "//"), "/", 2)
+	if len(nameSplit) < 2 {
+		return ""
+	}
+	return nameSplit[1]
+}
+
+// AssetNotFound reports whether err returned by GetAsset means the resource no longer exists
+func AssetNotFound(err error) bool {
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return apiErr.Code == http.StatusNotFound
+	}
+	return status.Code(err) == codes.NotFound
+}
+
+// InferAssetSchema returns the schema of a detailed table, every detailed table carries
+// the UpdatedTimestamp field used by bqQueryAssetCompare
+func InferAssetSchema(st interface{}) (bigquery.Schema, error) {
+	schema, err := InferSchema(st)
+	if err != nil {
+		return nil, err
+	}
+
+	field := bigquery.FieldSchema{}
+	field.Name = "UpdatedTimestamp"
+	field.Type = bigquery.TimestampFieldType
+	field.Required = false
+	field.Repeated = false
+	schema = append(schema, &field)
+
+	return schema, nil
+}
+
+// CreateAssetTable creates the detailed table of a handler when it does not exist yet,
+// fields added to the handler since the table was created are added to the existing table
+func CreateAssetTable(handler AssetHandler, projectID string, datasetID string) error {
+	assetTableID := handler.AssetTableID()
+	schema, err := handler.GetSchema()
+	if err != nil {
+		return fmt.Errorf("CreateAssetTable:%s:GetSchema: %v", assetTableID, err)
+	}
+
+	tableExist, err := bqTableExist(projectID, datasetID, assetTableID)
+	if err != nil {
+		return err
+	}
+	if tableExist {
+		return bqTableSchemaUpdate(projectID, datasetID, assetTableID, schema)
+	}
+	return bqTableCreate(projectID, datasetID, assetTableID, schema)
+}
+
+// InferAssetSchemaWithSelfLink is used by handlers whose API does not return a selfLink,
+// their rows carry a SelfLink column set to the asset name instead. The handler type embeds
+// the API type next to a SelfLink field that GetAsset sets and InsertAssetBQ requires.
+func InferAssetSchemaWithSelfLink(st interface{}) (bigquery.Schema, error) {
+	schema, err := InferAssetSchema(st)
+	if err != nil {
+		return nil, err
+	}
+
+	field := bigquery.FieldSchema{}
+	field.Name = "SelfLink"
+	field.Type = bigquery.StringFieldType
+	field.Required = false
+	field.Repeated = false
+	schema = append(schema, &field)
+
+	return schema, nil
+}
+
+// insertAsset loads the asset returned by a handler's GetAsset as one row of its detailed table
+func insertAsset(handler AssetHandler, projectID string, datasetID string) error {
+	schema, err := handler.GetSchema()
+	if err != nil {
+		return err
+	}
+
+	return bqAssetInsert(projectID, datasetID, handler.AssetTableID(), schema, handler)
+}
+
+func RefreshAssetInventory(handler AssetHandler, projectID string, datasetID string, assetInventoryTableID string) error {
+	assetTableID := handler.AssetTableID()
+	assetType := handler.AssetType()
+
+	// If the table does not exists then Create
+	if err := CreateAssetTable(handler, projectID, datasetID); err != nil {
+		return err
+	}
+
+	assets, err := bqQueryAssetCompare(projectID, datasetID, assetInventoryTableID, assetTableID, assetType)
+	if err != nil {
+		return err
+	}
+	for i := 0; i < len(assets); i++ {
+		asset := assets[i]
+		if HandlerDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+			fmt.Printf("DEBUG: RefreshAssetInventory:%s Action: %s Name: %s\n", assetTableID, asset.Action, asset.Name)
+		}
+		// The asset is read before the existing row is deleted, an UPDATE that can not be read keeps the old row
+		var assetDetail AssetHandler
+		if asset.Action != DELETE {
+			assetDetail, err = handler.GetAsset(asset.Name)
+			if err != nil {
+				// A single asset that can not be read should not stop the rest of the inventory
+				fmt.Printf("ERROR: RefreshAssetInventory:%s:GetAsset %s: %v\n", assetTableID, asset.Name, err)
+				continue
+			}
+		}
+		if asset.Action != CREATE {
+			if err := bqAssetDelete(projectID, datasetID, assetTableID, asset.SelfLink); err != nil {
+				return err
+			}
+		}
+		if asset.Action != DELETE {
+			if err := assetDetail.InsertAssetBQ(projectID, datasetID); err != nil {
+				fmt.Println(err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,36 @@
+package main
+
+import "testing"
+
+func TestAssetTableIDFromType(t *testing.T) {
+	tests := []struct {
+		assetType string
+		want      string
+	}{
+		{"compute.googleapis.com/Network", "compute_googleapis_com_Network"},
+		{"cloudresourcemanager.googleapis.com/Project", "cloudresourcemanager_googleapis_com_Project"},
+	}
+	for _, tt := range tests {
+		if got := AssetTableIDFromType(tt.assetType); got != tt.want {
+			t.Errorf("AssetTableIDFromType(%q) = %q, want %q", tt.assetType, got, tt.want)
+		}
+	}
+}
+
+func TestAssetRelativeName(t *testing.T) {
+	tests := []struct {
+		assetName string
+		want      string
+	}{
+		{"//redis.googleapis.com/projects/my-project/locations/us-central1/instances/cache", "projects/my-project/locations/us-central1/instances/cache"},
+		{"//storage.googleapis.com/my-bucket", "my-bucket"},
+		{"//cloudresourcemanager.googleapis.com/projects/123456", "projects/123456"},
+		{"projects/my-project/topics/feed", "projects/my-project/topics/feed"},
+		{"//pubsub.googleapis.com", ""},
+	}
+	for _, tt := range tests {
+		if got := AssetRelativeName(tt.assetName); got != tt.want {
+			t.Errorf("AssetRelativeName(%q) = %q, want %q", tt.assetName, got, tt.want)
+		}
+	}
+}
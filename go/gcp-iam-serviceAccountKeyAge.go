@@ -0,0 +1,52 @@
+package main
+
+// view_iam_ServiceAccountKey has one row per service account key with the key age and owning service account.
+// Stale is set for enabled user-managed keys older than env.GOOGLE_CLOUD_KEY_MAX_AGE_DAYS (default 90 days),
+// system-managed keys are rotated by Google and never flagged. The key may reference the service account
+// by unique id or email, both are matched.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_iam_ServiceAccountKey",
+		AssetTableIDs: []string{
+			(ServiceAccount{}).AssetTableID(),
+			(ServiceAccountKey{}).AssetTableID(),
+		},
+		Query: `
+			WITH serviceAccount AS (
+				SELECT serviceAccount.*, serviceAccountID
+				FROM ${dataset}.iam_googleapis_com_ServiceAccount AS serviceAccount,
+					UNNEST([serviceAccount.UniqueId, serviceAccount.Email]) AS serviceAccountID
+			),
+			serviceAccountKey AS (
+				SELECT
+					serviceAccountKey.SelfLink,
+					REGEXP_EXTRACT(serviceAccountKey.SelfLink, r'projects/[^/]+/serviceAccounts/([^/]+)/keys/') AS ServiceAccountID,
+					serviceAccountKey.KeyType,
+					serviceAccountKey.KeyOrigin,
+					serviceAccountKey.KeyAlgorithm,
+					serviceAccountKey.Disabled,
+					SAFE_CAST(serviceAccountKey.ValidAfterTime AS TIMESTAMP) AS ValidAfterTime,
+					SAFE_CAST(serviceAccountKey.ValidBeforeTime AS TIMESTAMP) AS ValidBeforeTime
+				FROM ${dataset}.iam_googleapis_com_ServiceAccountKey AS serviceAccountKey
+			)
+			SELECT
+				serviceAccountKey.SelfLink,
+				serviceAccount.SelfLink AS ServiceAccount,
+				serviceAccount.Email AS ServiceAccountEmail,
+				IFNULL(serviceAccount.Disabled, FALSE) AS ServiceAccountDisabled,
+				serviceAccountKey.KeyType,
+				serviceAccountKey.KeyType = 'USER_MANAGED' AS UserManaged,
+				serviceAccountKey.KeyOrigin,
+				serviceAccountKey.KeyAlgorithm,
+				IFNULL(serviceAccountKey.Disabled, FALSE) AS Disabled,
+				serviceAccountKey.ValidAfterTime,
+				serviceAccountKey.ValidBeforeTime,
+				DATE_DIFF(CURRENT_DATE(), DATE(serviceAccountKey.ValidAfterTime), DAY) AS KeyAgeDays,
+				serviceAccountKey.KeyType = 'USER_MANAGED'
+					AND NOT IFNULL(serviceAccountKey.Disabled, FALSE)
+					AND serviceAccountKey.ValidAfterTime < TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL ${key_max_age_days} DAY) AS Stale
+			FROM serviceAccountKey
+			LEFT JOIN serviceAccount
+				ON serviceAccount.serviceAccountID = serviceAccountKey.ServiceAccountID`,
+	})
+}
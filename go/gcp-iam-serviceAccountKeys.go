@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/iam/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// IamServiceAccountKey is the service account key returned by the IAM API, the private key is never
+// returned by get
+type IamServiceAccountKey iam.ServiceAccountKey
+
+// ServiceAccountKey stores IAM service account keys
+type ServiceAccountKey struct {
+	*IamServiceAccountKey
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&ServiceAccountKey{})
+}
+
+func (z ServiceAccountKey) AssetType() string {
+	return "iam.googleapis.com/ServiceAccountKey"
+}
+func (z ServiceAccountKey) AssetTableID() string {
+	return "iam_googleapis_com_ServiceAccountKey"
+}
+
+// https://cloud.google.com/iam/docs/reference/rest/v1/projects.serviceAccounts.keys/get
+func (z *ServiceAccountKey) GetAsset(assetName string) (AssetHandler, error) {
+	iamService, err := sharedIamService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //iam.googleapis.com/projects/PROJECT/serviceAccounts/UNIQUE_ID/keys/KEY
+	asset, err := iamService.Projects.ServiceAccounts.Keys.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceAccountKey{IamServiceAccountKey: (*IamServiceAccountKey)(asset), SelfLink: assetName}, nil
+}
+
+func (z ServiceAccountKey) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(IamServiceAccountKey{})
+}
+
+func (z *ServiceAccountKey) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.IamServiceAccountKey == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/iam/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// IamServiceAccount is the service account returned by the IAM API
+type IamServiceAccount iam.ServiceAccount
+
+// ServiceAccount stores IAM service accounts. The API names the service account by email while the asset
+// name uses the unique id, SelfLink is set to the asset name so rows match the inventory.
+type ServiceAccount struct {
+	*IamServiceAccount
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&ServiceAccount{})
+}
+
+func (z ServiceAccount) AssetType() string {
+	return "iam.googleapis.com/ServiceAccount"
+}
+func (z ServiceAccount) AssetTableID() string {
+	return "iam_googleapis_com_ServiceAccount"
+}
+
+// https://cloud.google.com/iam/docs/reference/rest/v1/projects.serviceAccounts/get
+func (z *ServiceAccount) GetAsset(assetName string) (AssetHandler, error) {
+	iamService, err := sharedIamService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //iam.googleapis.com/projects/PROJECT/serviceAccounts/UNIQUE_ID
+	asset, err := iamService.Projects.ServiceAccounts.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &ServiceAccount{IamServiceAccount: (*IamServiceAccount)(asset), SelfLink: assetName}, nil
+}
+
+func (z ServiceAccount) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(IamServiceAccount{})
+}
+
+func (z *ServiceAccount) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.IamServiceAccount == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
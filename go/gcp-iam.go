@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/iam/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpIamService() (*iam.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, iam.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return iam.New(client)
+}
+
+// sharedIamService returns a iam service that is created once and reused by every handler
+var sharedIamService = cachedService(gcpIamService)
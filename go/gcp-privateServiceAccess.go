@@ -0,0 +1,66 @@
+package main
+
+// view_network_PrivateServiceAccess lists the managed services consuming IP space in a VPC network
+// through private services access, one row per Cloud SQL, Memorystore or Filestore instance and network.
+// Network is the relative network name (projects/PROJECT/global/networks/NAME) reported by the service,
+// NetworkSelfLink is the matching row of the Network table. Filestore may only report the network name,
+// it is qualified with the project of the instance.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_network_PrivateServiceAccess",
+		AssetTableIDs: []string{
+			(Network{}).AssetTableID(),
+			(SqlInstance{}).AssetTableID(),
+			(RedisInstance{}).AssetTableID(),
+			(FilestoreInstance{}).AssetTableID(),
+		},
+		Query: `
+			WITH privateServiceAccess AS (
+				SELECT
+					'sqladmin.googleapis.com/Instance' AS AssetType,
+					sqlInstance.SelfLink,
+					sqlInstance.Name,
+					REGEXP_EXTRACT(sqlInstance.Settings.IpConfiguration.PrivateNetwork, r'projects/.*') AS Network,
+					ARRAY(SELECT ipAddress.IpAddress FROM UNNEST(sqlInstance.IpAddresses) AS ipAddress WHERE ipAddress.Type = 'PRIVATE') AS IpAddresses,
+					sqlInstance.Settings.IpConfiguration.AllocatedIpRange AS ReservedIpRange,
+					'PRIVATE_SERVICE_ACCESS' AS ConnectMode,
+					sqlInstance.Settings.IpConfiguration.Ipv4Enabled AS PublicIpEnabled,
+					ARRAY(SELECT authorizedNetwork.Value FROM UNNEST(sqlInstance.Settings.IpConfiguration.AuthorizedNetworks) AS authorizedNetwork) AS AuthorizedNetworks
+				FROM ${dataset}.sqladmin_googleapis_com_Instance AS sqlInstance
+				WHERE sqlInstance.Settings.IpConfiguration.PrivateNetwork IS NOT NULL
+				UNION ALL
+				SELECT
+					'redis.googleapis.com/Instance' AS AssetType,
+					redisInstance.SelfLink,
+					redisInstance.Name,
+					REGEXP_EXTRACT(redisInstance.AuthorizedNetwork, r'projects/.*') AS Network,
+					ARRAY(SELECT ipAddress FROM UNNEST([redisInstance.Host, redisInstance.ReadEndpoint]) AS ipAddress WHERE ipAddress IS NOT NULL) AS IpAddresses,
+					redisInstance.ReservedIpRange,
+					redisInstance.ConnectMode,
+					FALSE AS PublicIpEnabled,
+					CAST([] AS ARRAY<STRING>) AS AuthorizedNetworks
+				FROM ${dataset}.redis_googleapis_com_Instance AS redisInstance
+				UNION ALL
+				SELECT
+					'file.googleapis.com/Instance' AS AssetType,
+					filestoreInstance.SelfLink,
+					filestoreInstance.Name,
+					IF(STRPOS(network.Network, '/') > 0,
+						REGEXP_EXTRACT(network.Network, r'projects/.*'),
+						CONCAT(REGEXP_EXTRACT(filestoreInstance.Name, r'^(projects/[^/]+)/'), '/global/networks/', network.Network)) AS Network,
+					network.IpAddresses,
+					network.ReservedIpRange,
+					network.ConnectMode,
+					FALSE AS PublicIpEnabled,
+					CAST([] AS ARRAY<STRING>) AS AuthorizedNetworks
+				FROM ${dataset}.file_googleapis_com_Instance AS filestoreInstance,
+					UNNEST(filestoreInstance.Networks) AS network
+			)
+			SELECT
+				privateServiceAccess.*,
+				network.SelfLink AS NetworkSelfLink
+			FROM privateServiceAccess
+			LEFT JOIN ${dataset}.compute_googleapis_com_Network AS network
+				ON REGEXP_EXTRACT(network.SelfLink, r'projects/.*') = privateServiceAccess.Network`,
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+// view_pubsub_Subscription has one row per subscription linked to its topic and dead-letter topic by name.
+// ExternalPushEndpoint is set for push endpoints whose host is not a Cloud Run service, Cloud Function or
+// App Engine version of the inventory, not under a Cloud DNS managed zone of the inventory and not under
+// one of env.GOOGLE_CLOUD_ORG_DOMAINS (comma separated list of domains).
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_pubsub_Subscription",
+		AssetTableIDs: []string{
+			(Subscription{}).AssetTableID(),
+			(Topic{}).AssetTableID(),
+			(RunService{}).AssetTableID(),
+			(CloudFunction{}).AssetTableID(),
+			(AppEngineVersion{}).AssetTableID(),
+			(ManagedZone{}).AssetTableID(),
+		},
+		Query: `
+			WITH internalHost AS (
+				SELECT NET.HOST(runService.Uri) AS Host FROM ${dataset}.run_googleapis_com_Service AS runService
+				UNION ALL
+				SELECT NET.HOST(cloudFunction.HttpsTrigger.Url) FROM ${dataset}.cloudfunctions_googleapis_com_CloudFunction AS cloudFunction
+				UNION ALL
+				SELECT NET.HOST(appEngineVersion.VersionUrl) FROM ${dataset}.appengine_googleapis_com_Version AS appEngineVersion
+			),
+			internalDomain AS (
+				SELECT RTRIM(managedZone.DnsName, '.') AS Domain FROM ${dataset}.dns_googleapis_com_ManagedZone AS managedZone
+				UNION ALL
+				SELECT orgDomain FROM UNNEST(ARRAY<STRING>[${org_domains}]) AS orgDomain
+			),
+			subscription AS (
+				SELECT
+					subscription.*,
+					NET.HOST(subscription.PushConfig.PushEndpoint) AS PushEndpointHost
+				FROM ${dataset}.pubsub_googleapis_com_Subscription AS subscription
+			)
+			SELECT
+				subscription.SelfLink,
+				subscription.Name,
+				subscription.State,
+				subscription.Topic AS TopicName,
+				topic.SelfLink AS Topic,
+				subscription.Topic = '_deleted-topic_' AS TopicDeleted,
+				subscription.PushConfig.PushEndpoint,
+				subscription.PushEndpointHost,
+				subscription.PushEndpointHost IS NOT NULL
+					AND subscription.PushEndpointHost NOT IN (SELECT Host FROM internalHost WHERE Host IS NOT NULL)
+					AND NOT EXISTS(
+						SELECT 1 FROM internalDomain
+						WHERE subscription.PushEndpointHost = internalDomain.Domain OR ENDS_WITH(subscription.PushEndpointHost, CONCAT('.', internalDomain.Domain))) AS ExternalPushEndpoint,
+				subscription.PushConfig.OidcToken.ServiceAccountEmail AS PushServiceAccountEmail,
+				subscription.DeadLetterPolicy.DeadLetterTopic AS DeadLetterTopicName,
+				deadLetterTopic.SelfLink AS DeadLetterTopic,
+				subscription.DeadLetterPolicy.MaxDeliveryAttempts,
+				subscription.AckDeadlineSeconds,
+				subscription.MessageRetentionDuration,
+				subscription.RetainAckedMessages,
+				topic.MessageRetentionDuration AS TopicMessageRetentionDuration,
+				subscription.EnableMessageOrdering,
+				subscription.EnableExactlyOnceDelivery,
+				subscription.Filter,
+				subscription.Detached
+			FROM subscription
+			LEFT JOIN ${dataset}.pubsub_googleapis_com_Topic AS topic
+				ON topic.Name = subscription.Topic
+			LEFT JOIN ${dataset}.pubsub_googleapis_com_Topic AS deadLetterTopic
+				ON deadLetterTopic.Name = subscription.DeadLetterPolicy.DeadLetterTopic`,
+	})
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/pubsub/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// PubsubSubscription is the subscription returned by the Pub/Sub API
+type PubsubSubscription pubsub.Subscription
+
+// Subscription stores Pub/Sub subscriptions.
+// Labels replaces the label map of the API with a repeated key/value record.
+type Subscription struct {
+	*PubsubSubscription
+	SelfLink string           `json:"selfLink"`
+	Labels   []*ResourceLabel `json:"labels"`
+}
+
+func init() {
+	RegisterAssetHandler(&Subscription{})
+}
+
+func (z Subscription) AssetType() string {
+	return "pubsub.googleapis.com/Subscription"
+}
+func (z Subscription) AssetTableID() string {
+	return "pubsub_googleapis_com_Subscription"
+}
+
+// https://cloud.google.com/pubsub/docs/reference/rest/v1/projects.subscriptions/get
+func (z *Subscription) GetAsset(assetName string) (AssetHandler, error) {
+	pubSubService, err := sharedPubSubService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //pubsub.googleapis.com/projects/PROJECT/subscriptions/SUBSCRIPTION
+	asset, err := pubSubService.Projects.Subscriptions.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &Subscription{
+		PubsubSubscription: (*PubsubSubscription)(asset),
+		SelfLink:           assetName,
+		Labels:             resourceLabels(asset.Labels),
+	}, nil
+}
+
+func (z Subscription) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(PubsubSubscription{})
+	if err != nil {
+		return nil, err
+	}
+	labelSchema, err := InferSchema(struct{ Labels []*ResourceLabel }{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, labelSchema...), nil
+}
+
+func (z *Subscription) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.PubsubSubscription == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,69 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/pubsub/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// PubsubTopic is the topic returned by the Pub/Sub API
+type PubsubTopic pubsub.Topic
+
+// Topic stores Pub/Sub topics.
+// Labels replaces the label map of the API with a repeated key/value record.
+type Topic struct {
+	*PubsubTopic
+	SelfLink string           `json:"selfLink"`
+	Labels   []*ResourceLabel `json:"labels"`
+}
+
+func init() {
+	RegisterAssetHandler(&Topic{})
+}
+
+func (z Topic) AssetType() string {
+	return "pubsub.googleapis.com/Topic"
+}
+func (z Topic) AssetTableID() string {
+	return "pubsub_googleapis_com_Topic"
+}
+
+// https://cloud.google.com/pubsub/docs/reference/rest/v1/projects.topics/get
+func (z *Topic) GetAsset(assetName string) (AssetHandler, error) {
+	pubSubService, err := sharedPubSubService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //pubsub.googleapis.com/projects/PROJECT/topics/TOPIC
+	asset, err := pubSubService.Projects.Topics.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &Topic{
+		PubsubTopic: (*PubsubTopic)(asset),
+		SelfLink:    assetName,
+		Labels:      resourceLabels(asset.Labels),
+	}, nil
+}
+
+func (z Topic) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(PubsubTopic{})
+	if err != nil {
+		return nil, err
+	}
+	labelSchema, err := InferSchema(struct{ Labels []*ResourceLabel }{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, labelSchema...), nil
+}
+
+func (z *Topic) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.PubsubTopic == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/pubsub/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpPubSubService() (*pubsub.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, pubsub.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return pubsub.New(client)
+}
+
+// sharedPubSubService returns a pubsub service that is created once and reused by every handler
+var sharedPubSubService = cachedService(gcpPubSubService)
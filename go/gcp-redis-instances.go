@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/redis/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// MemorystoreRedisInstance is the Memorystore for Redis instance returned by the API
+type MemorystoreRedisInstance redis.Instance
+
+// RedisInstance stores Memorystore for Redis instances
+type RedisInstance struct {
+	*MemorystoreRedisInstance
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&RedisInstance{})
+}
+
+func (z RedisInstance) AssetType() string {
+	return "redis.googleapis.com/Instance"
+}
+func (z RedisInstance) AssetTableID() string {
+	return "redis_googleapis_com_Instance"
+}
+
+// https://cloud.google.com/memorystore/docs/redis/reference/rest/v1/projects.locations.instances/get
+func (z *RedisInstance) GetAsset(assetName string) (AssetHandler, error) {
+	redisService, err := sharedRedisService()
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := redisService.Projects.Locations.Instances.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &RedisInstance{MemorystoreRedisInstance: (*MemorystoreRedisInstance)(asset), SelfLink: assetName}, nil
+}
+
+func (z RedisInstance) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(MemorystoreRedisInstance{})
+}
+
+func (z *RedisInstance) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.MemorystoreRedisInstance == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
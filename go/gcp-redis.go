@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/redis/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpRedisService() (*redis.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, redis.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return redis.New(client)
+}
+
+// sharedRedisService returns a redis service that is created once and reused by every handler
+var sharedRedisService = cachedService(gcpRedisService)
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/run/v2"
+
+	"cloud.google.com/go/bigquery"
+)
+
+var ServerlessDebugLevel = DebugLevel(ERROR)
+
+// RunV2Service is the service returned by the Cloud Run Admin API v2
+type RunV2Service run.GoogleCloudRunV2Service
+
+// RunService stores Cloud Run services.
+// InvokerMembers are the members of the roles/run.invoker binding of the service IAM policy, PubliclyInvokable
+// is NULL when the IAM policy can not be read.
+type RunService struct {
+	*RunV2Service
+	SelfLink          string   `json:"selfLink"`
+	InvokerMembers    []string `json:"invokerMembers"`
+	PubliclyInvokable *bool    `json:"publiclyInvokable"`
+}
+
+func init() {
+	RegisterAssetHandler(&RunService{})
+}
+
+func (z RunService) AssetType() string {
+	return "run.googleapis.com/Service"
+}
+func (z RunService) AssetTableID() string {
+	return "run_googleapis_com_Service"
+}
+
+// https://cloud.google.com/run/docs/reference/rest/v2/projects.locations.services/get
+// https://cloud.google.com/run/docs/reference/rest/v2/projects.locations.services/getIamPolicy
+func (z *RunService) GetAsset(assetName string) (AssetHandler, error) {
+	runService, err := sharedRunService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //run.googleapis.com/projects/PROJECT/locations/LOCATION/services/SERVICE
+	serviceName := AssetRelativeName(assetName)
+
+	asset, err := runService.Projects.Locations.Services.Get(serviceName).Do()
+	if err != nil {
+		return nil, err
+	}
+	service := &RunService{RunV2Service: (*RunV2Service)(asset), SelfLink: assetName}
+
+	policy, err := runService.Projects.Locations.Services.GetIamPolicy(serviceName).Do()
+	if err != nil {
+		// The service is still recorded when the IAM policy can not be read
+		if ServerlessDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: RunService:GetAsset:GetIamPolicy %s: %v \n", serviceName, err)
+		}
+	} else {
+		for _, binding := range policy.Bindings {
+			if binding.Role == "roles/run.invoker" {
+				service.InvokerMembers = append(service.InvokerMembers, binding.Members...)
+			}
+		}
+	}
+	service.PubliclyInvokable = publiclyInvokable(service.InvokerMembers, err == nil)
+
+	return service, nil
+}
+
+func (z RunService) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchemaWithSelfLink(RunV2Service{})
+	if err != nil {
+		return nil, err
+	}
+
+	iamSchema, err := InferSchema(struct {
+		InvokerMembers    []string
+		PubliclyInvokable bool
+	}{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, iamSchema...), nil
+}
+
+// publiclyInvokable returns nil when iamKnown is false, a service whose IAM policy could not be read is
+// unknown rather than private
+func publiclyInvokable(invokerMembers []string, iamKnown bool) *bool {
+	if !(iamKnown) {
+		return nil
+	}
+	public := contains(invokerMembers, "allUsers") || contains(invokerMembers, "allAuthenticatedUsers")
+	return &public
+}
+
+func (z *RunService) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.RunV2Service == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/run/v2"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpRunService() (*run.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, run.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return run.New(client)
+}
+
+// sharedRunService returns a run service that is created once and reused by every handler
+var sharedRunService = cachedService(gcpRunService)
@@ -0,0 +1,23 @@
+package main
+
+import "sync"
+
+// cachedService wraps a service factory such as gcpComputeService, the service is created on the first call
+// and reused by every later call, a failed creation is retried by the next call
+func cachedService[T any](create func() (*T, error)) func() (*T, error) {
+	var mu sync.Mutex
+	var service *T
+	return func() (*T, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if service == nil {
+			s, err := create()
+			if err != nil {
+				return nil, err
+			}
+			service = s
+		}
+		return service, nil
+	}
+}
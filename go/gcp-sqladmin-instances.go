@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"google.golang.org/api/sqladmin/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// SqlInstance stores Cloud SQL instances, Settings.IpConfiguration holds the private network and authorized networks
+type SqlInstance sqladmin.DatabaseInstance
+
+func init() {
+	RegisterAssetHandler(&SqlInstance{})
+}
+
+func (z SqlInstance) AssetType() string {
+	return "sqladmin.googleapis.com/Instance"
+}
+func (z SqlInstance) AssetTableID() string {
+	return "sqladmin_googleapis_com_Instance"
+}
+
+// https://cloud.google.com/sql/docs/mysql/admin-api/rest/v1/instances/get
+func (z *SqlInstance) GetAsset(assetName string) (AssetHandler, error) {
+	sqladminService, err := sharedSqladminService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //cloudsql.googleapis.com/projects/PROJECT/instances/NAME
+	nameSplit := strings.Split(AssetRelativeName(assetName), "/")
+	if len(nameSplit) != 4 {
+		return nil, fmt.Errorf("SqlInstance:GetAsset %s is not a supported asset name", assetName)
+	}
+
+	asset, err := sqladminService.Instances.Get(nameSplit[1], nameSplit[3]).Do()
+	if err != nil {
+		return nil, err
+	}
+	return (*SqlInstance)(asset), nil
+}
+
+func (z SqlInstance) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchema(z)
+}
+
+func (z *SqlInstance) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/sqladmin/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpSqladminService() (*sqladmin.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, sqladmin.SqlserviceAdminScope)
+	if err != nil {
+		return nil, err
+	}
+	return sqladmin.New(client)
+}
+
+// sharedSqladminService returns a sqladmin service that is created once and reused by every handler
+var sharedSqladminService = cachedService(gcpSqladminService)
@@ -0,0 +1,143 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/storage/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+var StorageDebugLevel = DebugLevel(ERROR)
+
+// StorageBucket is the bucket metadata returned by the Cloud Storage API
+type StorageBucket storage.Bucket
+
+// Bucket stores Cloud Storage buckets with their IAM bindings, PubliclyReadable is derived from the
+// IAM bindings, the ACLs and public access prevention of the bucket. PubliclyReadable is NULL when the
+// IAM policy can not be read and the ACLs do not make the bucket public.
+type Bucket struct {
+	*StorageBucket
+	IamBindings      []*storage.PolicyBindings `json:"iamBindings"`
+	PubliclyReadable *bool                     `json:"publiclyReadable"`
+}
+
+func init() {
+	RegisterAssetHandler(&Bucket{})
+}
+
+func (z Bucket) AssetType() string {
+	return "storage.googleapis.com/Bucket"
+}
+func (z Bucket) AssetTableID() string {
+	return "storage_googleapis_com_Bucket"
+}
+
+// https://cloud.google.com/storage/docs/json_api/v1/buckets/get
+// https://cloud.google.com/storage/docs/json_api/v1/buckets/getIamPolicy
+func (z *Bucket) GetAsset(assetName string) (AssetHandler, error) {
+	storageService, err := sharedStorageService()
+	if err != nil {
+		return nil, err
+	}
+
+	// //storage.googleapis.com/BUCKET
+	bucketName := AssetRelativeName(assetName)
+
+	asset, err := storageService.Buckets.Get(bucketName).Projection("full").Do()
+	if err != nil {
+		return nil, err
+	}
+	// The storage selfLink (https://www.googleapis.com/storage/v1/b/BUCKET) is replaced by the asset name
+	// so bqQueryAssetCompare can match it with the inventory
+	asset.SelfLink = "//storage.googleapis.com/" + asset.Name
+
+	bucket := &Bucket{StorageBucket: (*StorageBucket)(asset)}
+
+	policy, err := storageService.Buckets.GetIamPolicy(bucketName).OptionsRequestedPolicyVersion(3).Do()
+	if err != nil {
+		// The bucket is still recorded when the IAM policy can not be read
+		if StorageDebugLevel.EnumIndex() >= DebugLevel(WARN).EnumIndex() {
+			fmt.Printf("WARNING: Bucket:GetAsset:GetIamPolicy %s: %v \n", bucketName, err)
+		}
+	} else {
+		bucket.IamBindings = policy.Bindings
+	}
+	bucket.PubliclyReadable = bucket.publiclyReadable(err == nil)
+
+	return bucket, nil
+}
+
+var publicMembers = []string{"allUsers", "allAuthenticatedUsers"}
+
+// Roles that allow reading objects or listing the bucket
+var publicReadRoles = []string{
+	"roles/storage.objectViewer",
+	"roles/storage.objectUser",
+	"roles/storage.objectAdmin",
+	"roles/storage.admin",
+	"roles/storage.legacyObjectReader",
+	"roles/storage.legacyObjectOwner",
+	"roles/storage.legacyBucketReader",
+	"roles/storage.legacyBucketWriter",
+	"roles/storage.legacyBucketOwner",
+}
+
+// publiclyReadable returns nil when the bucket is not public by its ACLs and iamKnown is false, a bucket
+// whose IAM policy could not be read is unknown rather than private
+func (z Bucket) publiclyReadable(iamKnown bool) *bool {
+	public, private := true, false
+	if z.IamConfiguration != nil && z.IamConfiguration.PublicAccessPrevention == "enforced" {
+		return &private
+	}
+	for _, binding := range z.IamBindings {
+		if !(contains(publicReadRoles, binding.Role)) {
+			continue
+		}
+		for _, member := range binding.Members {
+			if contains(publicMembers, member) {
+				return &public
+			}
+		}
+	}
+	// ACLs are ignored once uniform bucket-level access is enabled
+	if z.IamConfiguration == nil || z.IamConfiguration.UniformBucketLevelAccess == nil || !(z.IamConfiguration.UniformBucketLevelAccess.Enabled) {
+		for _, acl := range z.Acl {
+			if contains(publicMembers, acl.Entity) {
+				return &public
+			}
+		}
+		for _, acl := range z.DefaultObjectAcl {
+			if contains(publicMembers, acl.Entity) {
+				return &public
+			}
+		}
+	}
+	if !(iamKnown) {
+		return nil
+	}
+	return &private
+}
+
+func (z Bucket) GetSchema() (bigquery.Schema, error) {
+	schema, err := InferAssetSchema(StorageBucket{})
+	if err != nil {
+		return nil, err
+	}
+
+	iamSchema, err := InferSchema(struct {
+		IamBindings      []*storage.PolicyBindings
+		PubliclyReadable bool
+	}{})
+	if err != nil {
+		return nil, err
+	}
+	return append(schema, iamSchema...), nil
+}
+
+func (z *Bucket) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.StorageBucket == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
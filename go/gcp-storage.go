@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/storage/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpStorageService() (*storage.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, storage.CloudPlatformReadOnlyScope)
+	if err != nil {
+		return nil, err
+	}
+	return storage.New(client)
+}
+
+// sharedStorageService returns a storage service that is created once and reused by every handler
+var sharedStorageService = cachedService(gcpStorageService)
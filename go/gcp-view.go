@@ -0,0 +1,75 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+var ViewDebugLevel = DebugLevel(ERROR)
+
+// AssetView is a BigQuery view, or a derived table when Materialized is set, built on top of the detailed tables.
+// ${dataset} in Query is replaced by projectID.datasetID, any other ${name} by the matching view parameter.
+type AssetView struct {
+	ViewID        string
+	AssetTableIDs []string // Detailed tables referenced by Query
+	Query         string
+	Materialized  bool
+}
+
+var assetViews []AssetView
+
+// RegisterAssetView makes a view available to main(), it is expected to be called from init()
+func RegisterAssetView(view AssetView) {
+	for _, v := range assetViews {
+		if v.ViewID == view.ViewID {
+			panic(fmt.Sprintf("RegisterAssetView: view already registered for %s", view.ViewID))
+		}
+	}
+	assetViews = append(assetViews, view)
+}
+
+// RefreshAssetViews creates or replaces every registered view. Detailed tables referenced by a view are
+// created empty when the asset type is not part of the inventory so the view can still be created.
+func RefreshAssetViews(projectID string, datasetID string, parameters map[string]string) {
+	for _, view := range assetViews {
+		if err := view.Refresh(projectID, datasetID, parameters); err != nil {
+			fmt.Println(err)
+		}
+	}
+}
+
+// Expand returns Query with ${dataset} and the view parameters replaced
+func (v AssetView) Expand(projectID string, datasetID string, parameters map[string]string) string {
+	query := strings.Replace(v.Query, "${dataset}", fmt.Sprintf("%s.%s", projectID, datasetID), -1)
+	for name, value := range parameters {
+		query = strings.Replace(query, fmt.Sprintf("${%s}", name), value, -1)
+	}
+	return query
+}
+
+func (v AssetView) Refresh(projectID string, datasetID string, parameters map[string]string) error {
+	for _, assetTableID := range v.AssetTableIDs {
+		handler, exist := LookupAssetHandler(assetTableID)
+		if !(exist) {
+			return fmt.Errorf("AssetView:%s: no handler registered for %s", v.ViewID, assetTableID)
+		}
+		if err := CreateAssetTable(handler, projectID, datasetID); err != nil {
+			return fmt.Errorf("AssetView:%s: %v", v.ViewID, err)
+		}
+	}
+
+	query := v.Expand(projectID, datasetID, parameters)
+
+	kind := "VIEW"
+	if v.Materialized {
+		kind = "TABLE"
+	}
+	var queryString = fmt.Sprintf(`CREATE OR REPLACE %s %s.%s.%s AS %s`, kind, projectID, datasetID, v.ViewID, query)
+	if ViewDebugLevel.EnumIndex() >= DebugLevel(DEBUG).EnumIndex() {
+		fmt.Printf("DEBUG: AssetView:Refresh:QUERY `%s` \n", queryString)
+	}
+	if _, err := bqExecutQuery(projectID, queryString); err != nil {
+		return fmt.Errorf("AssetView:%s: %v", v.ViewID, err)
+	}
+	return nil
+}
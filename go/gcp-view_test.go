@@ -0,0 +1,56 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+// viewTestParameters has the parameter names main passes to RefreshAssetViews
+var viewTestParameters = map[string]string{
+	"snapshot_max_age_days": "30",
+	"asset_inventory_table": "cloudasset_googleapis_com_Asset",
+	"key_max_age_days":      "90",
+	"org_domains":           "'example.com'",
+}
+
+var viewTestTable = regexp.MustCompile(`\$\{dataset\}\.(\w+)`)
+
+func lookupAssetView(viewID string) (AssetView, bool) {
+	for _, view := range assetViews {
+		if view.ViewID == viewID {
+			return view, true
+		}
+	}
+	return AssetView{}, false
+}
+
+// Every placeholder of a view is resolved and every detailed table it reads is created by Refresh
+func TestAssetViews(t *testing.T) {
+	for _, view := range assetViews {
+		t.Run(view.ViewID, func(t *testing.T) {
+			query := view.Expand("my-project", "my_dataset", viewTestParameters)
+			if strings.Contains(query, "${") {
+				t.Errorf("unresolved placeholder in %s", query)
+			}
+			for _, assetTableID := range view.AssetTableIDs {
+				if _, exist := LookupAssetHandler(assetTableID); !(exist) {
+					t.Errorf("no handler registered for %s", assetTableID)
+				}
+			}
+			for _, match := range viewTestTable.FindAllStringSubmatch(view.Query, -1) {
+				if _, exist := LookupAssetHandler(match[1]); exist && !(contains(view.AssetTableIDs, match[1])) {
+					t.Errorf("%s is read but not in AssetTableIDs", match[1])
+				}
+			}
+		})
+	}
+}
+
+func TestAssetViewExpand(t *testing.T) {
+	view := AssetView{Query: "SELECT * FROM ${dataset}.${asset_inventory_table} WHERE age > ${snapshot_max_age_days} AND ${unknown}"}
+	want := "SELECT * FROM my-project.my_dataset.cloudasset_googleapis_com_Asset WHERE age > 30 AND ${unknown}"
+	if got := view.Expand("my-project", "my_dataset", viewTestParameters); got != want {
+		t.Errorf("Expand() = %s, want %s", got, want)
+	}
+}
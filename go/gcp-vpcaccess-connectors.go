@@ -0,0 +1,54 @@
+package main
+
+import (
+	"fmt"
+
+	"google.golang.org/api/vpcaccess/v1"
+
+	"cloud.google.com/go/bigquery"
+)
+
+// VpcaccessConnector is the Serverless VPC Access connector returned by the API
+type VpcaccessConnector vpcaccess.Connector
+
+// VpcAccessConnector stores Serverless VPC Access connectors
+type VpcAccessConnector struct {
+	*VpcaccessConnector
+	SelfLink string `json:"selfLink"`
+}
+
+func init() {
+	RegisterAssetHandler(&VpcAccessConnector{})
+}
+
+func (z VpcAccessConnector) AssetType() string {
+	return "vpcaccess.googleapis.com/Connector"
+}
+func (z VpcAccessConnector) AssetTableID() string {
+	return "vpcaccess_googleapis_com_Connector"
+}
+
+// https://cloud.google.com/vpc/docs/reference/vpcaccess/rest/v1/projects.locations.connectors/get
+func (z *VpcAccessConnector) GetAsset(assetName string) (AssetHandler, error) {
+	vpcAccessService, err := sharedVpcAccessService()
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := vpcAccessService.Projects.Locations.Connectors.Get(AssetRelativeName(assetName)).Do()
+	if err != nil {
+		return nil, err
+	}
+	return &VpcAccessConnector{VpcaccessConnector: (*VpcaccessConnector)(asset), SelfLink: assetName}, nil
+}
+
+func (z VpcAccessConnector) GetSchema() (bigquery.Schema, error) {
+	return InferAssetSchemaWithSelfLink(VpcaccessConnector{})
+}
+
+func (z *VpcAccessConnector) InsertAssetBQ(projectID string, datasetID string) error {
+	if z.VpcaccessConnector == nil || z.SelfLink == "" {
+		return fmt.Errorf("SelfLink is a required field")
+	}
+	return insertAsset(z, projectID, datasetID)
+}
@@ -0,0 +1,88 @@
+package main
+
+// view_network_ServerlessEgress has one row per Cloud Run service, Cloud Function and App Engine version with
+// its ingress setting, invoker members and VPC egress. VpcConnector is the relative connector name, short
+// names are qualified with the project and location of the resource. Network is the relative network name
+// of the connector, or of the Direct VPC egress interface of Cloud Run, NetworkSelfLink is the matching row
+// of the Network table. Connectors attached to a Shared VPC subnet use the subnet host project.
+// PubliclyInvokable is NULL when the IAM policy was not read and for App Engine versions, which have no
+// invoker role.
+func init() {
+	RegisterAssetView(AssetView{
+		ViewID: "view_network_ServerlessEgress",
+		AssetTableIDs: []string{
+			(RunService{}).AssetTableID(),
+			(CloudFunction{}).AssetTableID(),
+			(AppEngineVersion{}).AssetTableID(),
+			(VpcAccessConnector{}).AssetTableID(),
+			(Network{}).AssetTableID(),
+		},
+		Query: `
+			WITH serverless AS (
+				SELECT
+					'run.googleapis.com/Service' AS AssetType,
+					runService.SelfLink,
+					runService.Name,
+					runService.Uri AS Url,
+					runService.Ingress,
+					runService.Template.VpcAccess.Connector AS VpcConnector,
+					runService.Template.VpcAccess.Egress,
+					(SELECT networkInterface.Network FROM UNNEST(runService.Template.VpcAccess.NetworkInterfaces) AS networkInterface LIMIT 1) AS DirectVpcNetwork,
+					runService.InvokerMembers,
+					runService.PubliclyInvokable
+				FROM ${dataset}.run_googleapis_com_Service AS runService
+				UNION ALL
+				SELECT
+					'cloudfunctions.googleapis.com/CloudFunction' AS AssetType,
+					cloudFunction.SelfLink,
+					cloudFunction.Name,
+					cloudFunction.HttpsTrigger.Url,
+					cloudFunction.IngressSettings AS Ingress,
+					IF(STRPOS(cloudFunction.VpcConnector, '/') > 0,
+						cloudFunction.VpcConnector,
+						CONCAT(REGEXP_EXTRACT(cloudFunction.Name, r'^(projects/[^/]+/locations/[^/]+)/'), '/connectors/', cloudFunction.VpcConnector)) AS VpcConnector,
+					cloudFunction.VpcConnectorEgressSettings AS Egress,
+					CAST(NULL AS STRING) AS DirectVpcNetwork,
+					cloudFunction.InvokerMembers,
+					cloudFunction.PubliclyInvokable
+				FROM ${dataset}.cloudfunctions_googleapis_com_CloudFunction AS cloudFunction
+				UNION ALL
+				SELECT
+					'appengine.googleapis.com/Version' AS AssetType,
+					appEngineVersion.SelfLink,
+					appEngineVersion.Name,
+					appEngineVersion.VersionUrl AS Url,
+					appEngineVersion.IngressTrafficAllowed AS Ingress,
+					appEngineVersion.VpcAccessConnector.Name AS VpcConnector,
+					appEngineVersion.VpcAccessConnector.EgressSetting AS Egress,
+					CAST(NULL AS STRING) AS DirectVpcNetwork,
+					CAST([] AS ARRAY<STRING>) AS InvokerMembers,
+					CAST(NULL AS BOOL) AS PubliclyInvokable
+				FROM ${dataset}.appengine_googleapis_com_Version AS appEngineVersion
+			),
+			serverlessConnector AS (
+				SELECT
+					serverless.*,
+					connector.SelfLink AS VpcAccessConnector,
+					connector.State AS VpcAccessConnectorState,
+					connector.IpCidrRange AS VpcAccessConnectorIpCidrRange,
+					connector.Subnet.Name AS VpcAccessConnectorSubnet,
+					CASE
+						WHEN connector.SelfLink IS NOT NULL AND STRPOS(connector.Network, '/') > 0 THEN REGEXP_EXTRACT(connector.Network, r'projects/.*')
+						WHEN connector.SelfLink IS NOT NULL THEN CONCAT('projects/',
+							IFNULL(connector.Subnet.ProjectId, REGEXP_EXTRACT(connector.SelfLink, r'projects/([^/]+)/')), '/global/networks/', connector.Network)
+						WHEN STRPOS(serverless.DirectVpcNetwork, '/') > 0 THEN REGEXP_EXTRACT(serverless.DirectVpcNetwork, r'projects/.*')
+						WHEN serverless.DirectVpcNetwork IS NOT NULL THEN CONCAT(REGEXP_EXTRACT(serverless.Name, r'^(projects/[^/]+)/'), '/global/networks/', serverless.DirectVpcNetwork)
+					END AS Network
+				FROM serverless
+				LEFT JOIN ${dataset}.vpcaccess_googleapis_com_Connector AS connector
+					ON REGEXP_EXTRACT(connector.SelfLink, r'projects/.*') = serverless.VpcConnector
+			)
+			SELECT
+				serverlessConnector.*,
+				network.SelfLink AS NetworkSelfLink
+			FROM serverlessConnector
+			LEFT JOIN ${dataset}.compute_googleapis_com_Network AS network
+				ON REGEXP_EXTRACT(network.SelfLink, r'projects/.*') = serverlessConnector.Network`,
+	})
+}
@@ -0,0 +1,20 @@
+package main
+
+import (
+	"golang.org/x/net/context"
+	"google.golang.org/api/vpcaccess/v1"
+
+	"golang.org/x/oauth2/google"
+)
+
+func gcpVpcAccessService() (*vpcaccess.Service, error) {
+	ctx := context.Background()
+	client, err := google.DefaultClient(ctx, vpcaccess.CloudPlatformScope)
+	if err != nil {
+		return nil, err
+	}
+	return vpcaccess.New(client)
+}
+
+// sharedVpcAccessService returns a vpcaccess service that is created once and reused by every handler
+var sharedVpcAccessService = cachedService(gcpVpcAccessService)
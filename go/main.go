@@ -1,9 +1,13 @@
 package main
 
 import (
+	"flag"
 	"fmt"
 	"os"
+	"regexp"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var gcpRegions []string = []string{
@@ -37,6 +41,8 @@ var gcpRegions []string = []string{
 	"us-west4",
 }
 
+var orgDomainRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?(\.[a-z0-9]([a-z0-9-]*[a-z0-9])?)*$`)
+
 func contains(s []string, str string) bool {
 	for _, v := range s {
 		if v == str {
@@ -46,39 +52,90 @@ func contains(s []string, str string) bool {
 
 	return false
 }
-func main() {
-	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
-	if projectID == "" {
-		fmt.Println("env.GOOGLE_CLOUD_PROJECT environment variable must be set.")
-		os.Exit(1)
-	}
 
-	// https://cloud.google.com/asset-inventory/docs/supported-asset-types#supported_resource_types
-	assetTypes := strings.Split(os.Getenv("GOOGLE_CLOUD_ASSET_TYPES"), ",")
-	if len(assetTypes) == 1 && assetTypes[0] == "" {
-		fmt.Println("env.GOOGLE_CLOUD_ASSET_TYPES environment variable must be set and contain atleast one item")
-		os.Exit(1)
+// splitList splits a comma separated list, items are trimmed and empty items are dropped
+func splitList(list string) []string {
+	var items []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
 	}
+	return items
+}
+
+// assetScopeFromEnv reads env.GOOGLE_CLOUD_ASSET_SCOPE (e.g. projects/my-project), the scope is also returned
+// split into scope type and ID with - replaced by _ so it can be used in a dataset name
+func assetScopeFromEnv() (string, []string, error) {
 	assetScopes := []string{"projects", "folders", "organizations"}
 
 	assetScope := strings.ToLower(os.Getenv("GOOGLE_CLOUD_ASSET_SCOPE"))
 
 	if assetScope == "" {
-		err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_SCOPE: environment variable must be set.")
-		fmt.Println(err.Error())
-		os.Exit(1)
+		return "", nil, fmt.Errorf("env.GOOGLE_CLOUD_ASSET_SCOPE: environment variable must be set.")
 	}
 	_assetScope := strings.Split(strings.Replace(assetScope, "-", "_", -1), "/")
 	if !(contains(assetScopes, _assetScope[0])) {
-		err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_SCOPE: The scope type `%s` is not one of the supported scopes types %v", _assetScope, assetScopes)
-		fmt.Println(err.Error())
-		os.Exit(1)
+		return "", nil, fmt.Errorf("env.GOOGLE_CLOUD_ASSET_SCOPE: The scope type `%s` is not one of the supported scopes types %v", _assetScope, assetScopes)
+	}
+	if len(_assetScope) != 2 || _assetScope[1] == "" {
+		return "", nil, fmt.Errorf("env.GOOGLE_CLOUD_ASSET_SCOPE: `%s` must be formatted as %s/ID", assetScope, _assetScope[0])
 	}
+	return assetScope, _assetScope, nil
+}
 
+// datasetIDFromEnv reads env.GOOGLE_CLOUD_DATASET_ID, the default dataset is named after the asset scope
+func datasetIDFromEnv(_assetScope []string) string {
 	datasetID := os.Getenv("GOOGLE_CLOUD_DATASET_ID")
 	if datasetID == "" {
 		datasetID = fmt.Sprintf(`gcp_asset_inventory_%s_%s`, _assetScope[0], _assetScope[1])
 	}
+	return datasetID
+}
+
+func main() {
+	if len(os.Args) > 1 {
+		var command func([]string) error
+		switch os.Args[1] {
+		case "search":
+			// search is an ad-hoc lookup with SearchAllResources or SearchAllIamPolicies, the inventory is not refreshed
+			command = SearchCommand
+		case "feed":
+			// feed keeps the detailed tables up to date from a Cloud Asset Feed until interrupted
+			command = FeedCommand
+		case "analyze-iam":
+			// analyze-iam writes who has which access on which resource (groups expanded) next to the inventory
+			command = AnalyzeIamCommand
+		}
+		if command != nil {
+			// -h prints the usage of the subcommand and is not an error
+			if err := command(os.Args[2:]); err != nil && err != flag.ErrHelp {
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+			return
+		}
+	}
+
+	projectID := os.Getenv("GOOGLE_CLOUD_PROJECT")
+	if projectID == "" {
+		fmt.Println("env.GOOGLE_CLOUD_PROJECT environment variable must be set.")
+		os.Exit(1)
+	}
+
+	// https://cloud.google.com/asset-inventory/docs/supported-asset-types#supported_resource_types
+	assetTypes := splitList(os.Getenv("GOOGLE_CLOUD_ASSET_TYPES"))
+	if len(assetTypes) == 0 {
+		fmt.Println("env.GOOGLE_CLOUD_ASSET_TYPES environment variable must be set and contain atleast one item")
+		os.Exit(1)
+	}
+	assetScope, _assetScope, err := assetScopeFromEnv()
+	if err != nil {
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	datasetID := datasetIDFromEnv(_assetScope)
 
 	datasetRegion := strings.ToLower(os.Getenv("GOOGLE_CLOUD_DATASET_REGION"))
 	datasetRegions := append(gcpRegions, "us", "eu")
@@ -94,34 +151,145 @@ func main() {
 	if assetInventoryTableID == "" {
 		assetInventoryTableID = "cloudasset_googleapis_com_Asset"
 	}
-	AssetDebugLevel = DEBUG
-	asset := Asset{}
+	snapshotMaxAgeDays := os.Getenv("GOOGLE_CLOUD_SNAPSHOT_MAX_AGE_DAYS")
+	if snapshotMaxAgeDays == "" {
+		snapshotMaxAgeDays = "30"
+	} else if days, err := strconv.Atoi(snapshotMaxAgeDays); err != nil || days < 0 {
+		err := fmt.Errorf("env.GOOGLE_CLOUD_SNAPSHOT_MAX_AGE_DAYS: `%s` is not a number of days >= 0", snapshotMaxAgeDays)
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+	// Point in time inventory, RFC3339 timestamp or date (midnight UTC) at most 35 days in the past
+	var readTime time.Time
+	if assetReadTime := os.Getenv("GOOGLE_CLOUD_ASSET_READ_TIME"); assetReadTime != "" {
+		if readTime, err = time.Parse(time.RFC3339, assetReadTime); err != nil {
+			if readTime, err = time.Parse("2006-01-02", assetReadTime); err != nil {
+				err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_READ_TIME: `%s` is not a RFC3339 timestamp or YYYY-MM-DD date", assetReadTime)
+				fmt.Println(err.Error())
+				os.Exit(1)
+			}
+		}
+		if readTime.After(time.Now()) || readTime.Before(time.Now().AddDate(0, 0, -35)) {
+			err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_READ_TIME: `%s` must be within the last 35 days", assetReadTime)
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
+
+	// list reads every asset with ListAssets and streams it into the inventory table, export lets
+	// ExportAssets write the assets to BigQuery which scales to large organizations
+	collectionModes := []string{"list", "export"}
+	collectionMode := strings.ToLower(os.Getenv("GOOGLE_CLOUD_ASSET_COLLECTION_MODE"))
+	if collectionMode == "" {
+		collectionMode = "list"
+	} else if !(contains(collectionModes, collectionMode)) {
+		err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_COLLECTION_MODE: `%s` is not one of the supported modes %v", collectionMode, collectionModes)
+		fmt.Println(err.Error())
+		os.Exit(1)
+	}
+
+	// Change history of the inventory assets, BatchGetAssetsHistory keeps 35 days and does not accept folders
+	var historyDays int
+	if assetHistoryDays := os.Getenv("GOOGLE_CLOUD_ASSET_HISTORY_DAYS"); assetHistoryDays != "" {
+		if historyDays, err = strconv.Atoi(assetHistoryDays); err != nil || historyDays < 1 || historyDays > 35 {
+			err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_HISTORY_DAYS: `%s` is not a number of days between 1 and 35", assetHistoryDays)
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		if _assetScope[0] == "folders" {
+			err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_HISTORY_DAYS: The scope type `%s` is not supported by BatchGetAssetsHistory", _assetScope[0])
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+	}
 
-	if err := asset.CollectAssets(assetScope, assetTypes); err != nil {
+	// https://cloud.google.com/asset-inventory/docs/reference/rest/v1/feeds#contenttype
+	var assetContents []AssetContent
+	for _, contentType := range splitList(os.Getenv("GOOGLE_CLOUD_ASSET_CONTENT_TYPES")) {
+		content, exist := LookupAssetContent(contentType)
+		if !(exist) {
+			err := fmt.Errorf("env.GOOGLE_CLOUD_ASSET_CONTENT_TYPES: The content type `%s` is not one of the supported content types %v", contentType, AssetContentNames())
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		assetContents = append(assetContents, content)
+	}
+	keyMaxAgeDays := os.Getenv("GOOGLE_CLOUD_KEY_MAX_AGE_DAYS")
+	if keyMaxAgeDays == "" {
+		keyMaxAgeDays = "90"
+	} else if days, err := strconv.Atoi(keyMaxAgeDays); err != nil || days < 0 {
+		err := fmt.Errorf("env.GOOGLE_CLOUD_KEY_MAX_AGE_DAYS: `%s` is not a number of days >= 0", keyMaxAgeDays)
+		fmt.Println(err.Error())
 		os.Exit(1)
 	}
-	asset.RefreshInventory(projectID, datasetID, datasetRegion, assetInventoryTableID)
+	// Domains are validated before they are added to the view queries as a BigQuery array
+	orgDomains := []string{}
+	for _, orgDomain := range splitList(strings.ToLower(os.Getenv("GOOGLE_CLOUD_ORG_DOMAINS"))) {
+		if !(orgDomainRegex.MatchString(orgDomain)) {
+			err := fmt.Errorf("env.GOOGLE_CLOUD_ORG_DOMAINS: `%s` is not a domain name", orgDomain)
+			fmt.Println(err.Error())
+			os.Exit(1)
+		}
+		orgDomains = append(orgDomains, fmt.Sprintf("'%s'", orgDomain))
+	}
+	viewParameters := map[string]string{
+		"snapshot_max_age_days": snapshotMaxAgeDays,
+		"asset_inventory_table": assetInventoryTableID,
+		"key_max_age_days":      keyMaxAgeDays,
+		"org_domains":           strings.Join(orgDomains, ","),
+	}
+
+	AssetDebugLevel = DEBUG
+	asset := Asset{ReadTime: readTime}
+
+	if collectionMode == "export" {
+		if err := asset.ExportInventory(projectID, datasetID, datasetRegion, AssetInventoryTableID(assetInventoryTableID, readTime), AssetExportTableID(assetInventoryTableID), assetScope, assetTypes); err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+	} else {
+		if err := asset.CollectAssets(assetScope, assetTypes); err != nil {
+			os.Exit(1)
+		}
+		asset.RefreshInventory(projectID, datasetID, datasetRegion, AssetInventoryTableID(assetInventoryTableID, readTime))
+	}
+	for _, content := range assetContents {
+		if err := content.Refresh(projectID, datasetID, assetScope, assetTypes, readTime); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if historyDays > 0 {
+		if err := CollectAssetHistory(projectID, datasetID, AssetInventoryTableID(assetInventoryTableID, readTime), AssetHistoryTableID(assetInventoryTableID), assetScope, historyDays); err != nil {
+			fmt.Println(err)
+		}
+	}
+	if !(readTime.IsZero()) {
+		// Handlers read the current state of every resource, a point in time inventory only keeps the
+		// resource data returned by ListAssets
+		fmt.Printf("Point in time inventory as of %s written to %s\n", readTime.UTC().Format(time.RFC3339), AssetInventoryTableID(assetInventoryTableID, readTime))
+		return
+	}
 	assetTableIDs := asset.ListDistinctAssets(projectID, datasetID, assetInventoryTableID)
 
 	for i := 0; i < len(assetTableIDs); i++ {
-		switch assetTableID := assetTableIDs[i]; assetTableID {
-		case (ForwardingRule{}).AssetTableID():
-			fmt.Printf("Funciton Exist for:> %s\n", assetTableID)
-			(&ForwardingRule{}).RefreshAssetInventory(projectID, datasetID, assetInventoryTableID)
-		case (Network{}).AssetTableID():
-			fmt.Printf("Funciton Exist for:> %s\n", assetTableID)
-			(&Network{}).RefreshAssetInventory(projectID, datasetID, assetInventoryTableID)
-		case (Subnetwork{}).AssetTableID():
+		assetTableID := assetTableIDs[i]
+		handler, exist := LookupAssetHandler(assetTableID)
+		if exist {
 			fmt.Printf("Funciton Exist for:> %s\n", assetTableID)
-			(&Subnetwork{}).RefreshAssetInventory(projectID, datasetID, assetInventoryTableID)
-		case (Instance{}).AssetTableID():
-			fmt.Printf("Funciton Exist for:> %s\n", assetTableID)
-			(&Instance{}).RefreshAssetInventory(projectID, datasetID, assetInventoryTableID)
-		case (Address{}).AssetTableID():
-			fmt.Printf("Funciton Exist for:> %s\n", assetTableID)
-			(&Address{}).RefreshAssetInventory(projectID, datasetID, assetInventoryTableID)
-		default:
-			fmt.Printf("No funciton defined for:> %s\n", assetTableID)
+		} else {
+			// Asset types without a hand-written handler are built from the discovery document
+			discoveryAsset, err := NewDiscoveryAsset(projectID, datasetID, assetInventoryTableID, asset.DistinctAssetList[i])
+			if err != nil {
+				fmt.Printf("No funciton defined for:> %s %v\n", assetTableID, err)
+				continue
+			}
+			fmt.Printf("Discovery funciton used for:> %s\n", assetTableID)
+			handler = discoveryAsset
+		}
+		if err := RefreshAssetInventory(handler, projectID, datasetID, assetInventoryTableID); err != nil {
+			fmt.Println(err)
 		}
 	}
+
+	RefreshAssetViews(projectID, datasetID, viewParameters)
 }